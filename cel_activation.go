@@ -0,0 +1,48 @@
+package protoeval
+
+import (
+	"github.com/google/cel-go/interpreter"
+)
+
+// celActivation resolves the variables available to CEL programs on demand.
+// This way, programs only pay for the conversion of the variables they
+// actually reference.
+type celActivation struct {
+	// env is the environment the program is evaluated in.
+	env *Env
+
+	// scope is the CEL scope. If nil, the CEL scope has not been materialized
+	// yet.
+	scope *celScope
+}
+
+var _ interpreter.Activation = &celActivation{}
+
+// newCelActivation creates a new CEL activation for the given environment.
+func newCelActivation(env *Env) *celActivation {
+	return &celActivation{
+		env: env,
+	}
+}
+
+// ResolveName implements interpreter.Activation.ResolveName.
+func (ca *celActivation) ResolveName(name string) (interface{}, bool) {
+	switch name {
+	case "env":
+		return (*celEnv)(ca.env), true
+	case "scope":
+		if ca.scope == nil {
+			ca.scope = newCelScope(&ca.env.scope)
+		}
+		return ca.scope, true
+	case "args":
+		return (*celArgList)(&ca.env.scope), true
+	default:
+		return nil, false
+	}
+}
+
+// Parent implements interpreter.Activation.Parent.
+func (*celActivation) Parent() interpreter.Activation {
+	return nil
+}
@@ -0,0 +1,328 @@
+package protoeval
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/google/cel-go/common/types"
+	"github.com/google/cel-go/common/types/ref"
+	"github.com/google/cel-go/common/types/traits"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// CEL scope keys
+const (
+	// celScopeValue is the key of the native scope value.
+	celScopeValue = "value"
+
+	// celScopeList is the key of the scope value if it is a list.
+	celScopeList = "list"
+
+	// celScopeMap is the key of the scope value if it is a map.
+	celScopeMap = "map"
+
+	// celScopeParent is the key of the parent scope.
+	celScopeParent = "parent"
+
+	// celScopeFieldDescriptor is the key of the field descriptor leading up to
+	// the scope value.
+	celScopeFieldDescriptor = "field_descriptor"
+)
+
+// celScopeType carries type information for celScope.
+type celScopeType struct{}
+
+var _ ref.Type = &celScopeType{}
+
+// HasTrait implements ref.Type.HasTrait.
+func (*celScopeType) HasTrait(tr int) bool {
+	const availableTraits = traits.ContainerType |
+		traits.IndexerType |
+		traits.IterableType |
+		traits.SizerType
+	return tr&availableTraits != 0
+}
+
+// TypeName implements ref.Type.TypeName.
+func (*celScopeType) TypeName() string {
+	return "com.github.thecount.protoeval.scope"
+}
+
+// CelScopeType is the CEL type for a protoeval scope.
+var CelScopeType = &celScopeType{}
+
+// celScope uses a scope to provide a traits.Mapper for the current scope.
+// The scope value is presented natively, i. e., messages have their actual
+// message type, and lists and maps are typed (maps with their actual key
+// types). The scope value and the parent scopes are materialized only when
+// accessed, and then cached.
+type celScope struct {
+	// scope is the underlying scope.
+	scope *scope
+
+	// value is the cached scope value. If nil, the scope value has not been
+	// materialized yet.
+	value ref.Val
+
+	// parent is the cached parent CEL scope. If nil, the parent has not been
+	// materialized yet, or scope is the root scope.
+	parent *celScope
+}
+
+var _ traits.Mapper = &celScope{}
+
+// newCelScope creates a new CEL scope for the given scope.
+func newCelScope(s *scope) *celScope {
+	return &celScope{
+		scope: s,
+	}
+}
+
+// keys returns the keys present in this CEL scope, in a fixed order.
+func (cs *celScope) keys() []string {
+	result := make([]string, 0, 4)
+	result = append(result, celScopeValue)
+	if cs.scope.multi != nil {
+		result = append(result, celScopeList)
+	} else {
+		switch cs.scope.value.Interface().(type) {
+		case protoreflect.List:
+			result = append(result, celScopeList)
+		case protoreflect.Map:
+			result = append(result, celScopeMap)
+		}
+	}
+	if cs.scope.parent != nil {
+		result = append(result, celScopeParent)
+	}
+	if cs.scope.desc != nil {
+		result = append(result, celScopeFieldDescriptor)
+	}
+	return result
+}
+
+// get returns the value for the given key, or nil if there is no such key.
+func (cs *celScope) get(key string) ref.Val {
+	for _, k := range cs.keys() {
+		if k != key {
+			continue
+		}
+		switch key {
+		case celScopeValue, celScopeList, celScopeMap:
+			return cs.scopeValue()
+		case celScopeParent:
+			return cs.parentScope()
+		case celScopeFieldDescriptor:
+			return celTypeRegistry.NativeToValue(
+				protodesc.ToFieldDescriptorProto(cs.scope.desc))
+		default:
+			panic(fmt.Sprintf("BUG: unhandled CEL scope key %s", key))
+		}
+	}
+	return nil
+}
+
+// scopeValue returns the scope value, materializing it if necessary.
+func (cs *celScope) scopeValue() ref.Val {
+	if cs.value == nil {
+		cs.value = cs.scope.Value()
+	}
+	return cs.value
+}
+
+// parentScope returns the parent CEL scope, materializing it if necessary.
+// If this is the root scope, nil is returned.
+func (cs *celScope) parentScope() *celScope {
+	if cs.parent == nil && cs.scope.parent != nil {
+		cs.parent = newCelScope(cs.scope.parent)
+	}
+	return cs.parent
+}
+
+// asMapStringInterface returns this CEL scope as a map[string]interface{}.
+// Parent scopes are converted recursively.
+func (cs *celScope) asMapStringInterface() map[string]interface{} {
+	keys := cs.keys()
+	result := make(map[string]interface{}, len(keys))
+	for _, k := range keys {
+		result[k] = cs.get(k).Value()
+	}
+	return result
+}
+
+// Contains implements traits.Container.Contains for traits.Mapper.
+func (cs *celScope) Contains(value ref.Val) ref.Val {
+	key, ok := value.Value().(string)
+	if !ok {
+		return types.False
+	}
+	return types.Bool(cs.get(key) != nil)
+}
+
+// ConvertToNative implements ref.Val.ConvertToNative for traits.Mapper.
+func (cs *celScope) ConvertToNative(t reflect.Type) (interface{}, error) {
+	if t == reflect.TypeOf(map[string]interface{}(nil)) {
+		return cs.asMapStringInterface(), nil
+	}
+	return nil, fmt.Errorf("conversion to type %s not supported", t)
+}
+
+// ConvertToType implements ref.Val.ConvertToType for traits.Mapper.
+func (cs *celScope) ConvertToType(t ref.Type) ref.Val {
+	switch t {
+	case CelScopeType:
+		return cs
+	case types.TypeType:
+		return types.NewObjectTypeValue(CelScopeType.TypeName())
+	}
+	return types.NewErr("type conversion error from '%s' to '%s'",
+		CelScopeType.TypeName(), t.TypeName())
+}
+
+// Equal implements ref.Val.Equal for traits.Mapper.
+func (cs *celScope) Equal(other ref.Val) ref.Val {
+	if cs.Type() != other.Type() {
+		return types.False
+	}
+	otherScope := other.(*celScope)
+	for cs != otherScope {
+		if cs == nil || otherScope == nil {
+			return types.False
+		}
+		if cs.scope == otherScope.scope {
+			return types.True
+		}
+		if cs.scope.desc != otherScope.scope.desc {
+			return types.False
+		}
+		if cs.scopeValue().Equal(otherScope.scopeValue()) != types.True {
+			return types.False
+		}
+		cs, otherScope = cs.parentScope(), otherScope.parentScope()
+	}
+	return types.True
+}
+
+// Find implements traits.Mapper.Find.
+func (cs *celScope) Find(key ref.Val) (ref.Val, bool) {
+	k, ok := key.Value().(string)
+	if !ok {
+		return types.ValOrErr(key, "invalid key type %s",
+			key.Type().TypeName()), false
+	}
+	v := cs.get(k)
+	if v == nil {
+		return nil, false
+	}
+	return v, true
+}
+
+// Get implements traits.Indexer.Get for traits.Mapper.
+func (cs *celScope) Get(key ref.Val) ref.Val {
+	k, ok := key.Value().(string)
+	if !ok {
+		return types.ValOrErr(key, "invalid key type %s", key.Type().TypeName())
+	}
+	v := cs.get(k)
+	if v == nil {
+		return types.NewErr("key '%s' not found", k)
+	}
+	return v
+}
+
+// Iterator implements traits.Iterable.Iterator for traits.Mapper.
+func (cs *celScope) Iterator() traits.Iterator {
+	return &celScopeIterator{
+		keys: cs.keys(),
+		pos:  0,
+	}
+}
+
+// Size implements traits.Sizer.Size for traits.Mapper.
+func (cs *celScope) Size() ref.Val {
+	return types.Int(len(cs.keys()))
+}
+
+// Type implements ref.Val.Type for traits.Mapper.
+func (cs *celScope) Type() ref.Type {
+	return CelScopeType
+}
+
+// Value implements ref.Val.Value for traits.Mapper.
+func (cs *celScope) Value() interface{} {
+	return cs.asMapStringInterface()
+}
+
+// celScopeIteratorType carries type information for celScopeIterator.
+type celScopeIteratorType struct{}
+
+var _ ref.Type = &celScopeIteratorType{}
+
+// HasTrait implements ref.Type.HasTrait.
+func (*celScopeIteratorType) HasTrait(tr int) bool {
+	return tr&traits.IteratorType != 0
+}
+
+// TypeName implements ref.Type.TypeName.
+func (*celScopeIteratorType) TypeName() string {
+	return "com.github.thecount.protoeval.scope.iterator"
+}
+
+// celScopeIteratorTypeInstance is the CEL type for a scope iterator.
+var celScopeIteratorTypeInstance = &celScopeIteratorType{}
+
+// celScopeIterator implements the traits.Iterator interface for celScope.
+type celScopeIterator struct {
+	// keys is the list of scope keys.
+	keys []string
+
+	// pos is the current iterator position into keys. Once the iteration has
+	// finished, pos == len(keys).
+	pos int
+}
+
+// ConvertToNative implements ref.Value.ConvertToNative for traits.Iterator.
+func (*celScopeIterator) ConvertToNative(t reflect.Type) (
+	interface{}, error,
+) {
+	return nil, errors.New("internal iterator type not convertible")
+}
+
+// ConvertToType implements ref.Value.ConvertToType for traits.Iterator.
+func (*celScopeIterator) ConvertToType(t ref.Type) ref.Val {
+	return types.NewErr("internal iterator type not convertible")
+}
+
+// Equal implements ref.Value.Equal for traits.Iterator.
+func (iter *celScopeIterator) Equal(other ref.Val) ref.Val {
+	if iter.Type() != other.Type() {
+		return types.False
+	}
+	otherIter := other.(*celScopeIterator)
+	return types.Bool(reflect.DeepEqual(iter.keys, otherIter.keys) &&
+		iter.pos == otherIter.pos)
+}
+
+// HasNext implements traits.Iterator.HasNext.
+func (iter *celScopeIterator) HasNext() ref.Val {
+	return types.Bool(iter.pos < len(iter.keys))
+}
+
+// Next implements traits.Iterator.Next.
+func (iter *celScopeIterator) Next() ref.Val {
+	rv := types.String(iter.keys[iter.pos])
+	iter.pos++
+	return rv
+}
+
+// Type implements ref.Val.Type for traits.Iterator.
+func (iter *celScopeIterator) Type() ref.Type {
+	return celScopeIteratorTypeInstance
+}
+
+// Value implements ref.Val.Value for traits.Iterator.
+func (iter *celScopeIterator) Value() interface{} {
+	return iter
+}
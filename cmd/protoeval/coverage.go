@@ -0,0 +1,33 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/TheCount/protoeval"
+)
+
+// writeCoverage writes a coverage report for value to the file at the given
+// path. The report is in HTML format if the file extension is .html or
+// .htm, and in text format otherwise.
+func writeCoverage(
+	path string, coverage *protoeval.Coverage, value *protoeval.Value,
+	title string,
+) (err error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if cerr := f.Close(); err == nil {
+			err = cerr
+		}
+	}()
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".html", ".htm":
+		return coverage.WriteHTML(f, value, title)
+	default:
+		return coverage.WriteText(f, value)
+	}
+}
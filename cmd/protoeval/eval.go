@@ -0,0 +1,148 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/TheCount/protoeval"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// evalFlags are the flags common to commands evaluating Values.
+type evalFlags struct {
+	descriptorSets stringList
+	typeName       string
+	inputPath      string
+	inputFormat    string
+	args           stringList
+	env            stringList
+}
+
+// register registers these flags with the given flag set.
+func (f *evalFlags) register(fs *flag.FlagSet) {
+	fs.Var(&f.descriptorSets, "descriptor-set",
+		"`file` containing a FileDescriptorSet with the message types "+
+			"(repeatable)")
+	fs.StringVar(&f.typeName, "type", "",
+		"full `name` of the input message type (required)")
+	fs.StringVar(&f.inputPath, "input", "-",
+		"input message `file`, - for stdin")
+	fs.StringVar(&f.inputFormat, "input-format", "",
+		"input message `format`: json, text, or binary "+
+			"(default: inferred from file extension, or binary)")
+	fs.Var(&f.args, "arg",
+		"JSON encoded evaluation argument `value` (repeatable, in order)")
+	fs.Var(&f.env, "env",
+		"environment value as `key=value`, with JSON encoded value (repeatable)")
+}
+
+// setup registers the descriptor sets, and loads the input message and the
+// evaluation arguments, and creates the environment.
+func (f *evalFlags) setup(stdin io.Reader) (
+	env *protoeval.Env, msg proto.Message, args []interface{}, err error,
+) {
+	for _, path := range f.descriptorSets {
+		if err = registerDescriptorSet(path); err != nil {
+			return nil, nil, nil, err
+		}
+	}
+	mt, err := findMessageType(f.typeName)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	msg, err = loadMessage(f.inputPath, f.inputFormat, mt, stdin)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	for i, text := range f.args {
+		arg, err := parseJSONValue(text)
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("arg %d: %w", i, err)
+		}
+		args = append(args, arg)
+	}
+	env, err = newEnv(f.env)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return env, msg, args, nil
+}
+
+// runEval runs the eval command.
+func runEval(args []string, stdin io.Reader, stdout, stderr io.Writer) error {
+	fs := flag.NewFlagSet("eval", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: protoeval eval -value FILE -type NAME [flags]")
+		fmt.Fprintln(stderr)
+		fmt.Fprintln(stderr, "Evaluates a Value against an input message and "+
+			"prints the result as JSON.")
+		fmt.Fprintln(stderr)
+		fs.PrintDefaults()
+	}
+	var flags evalFlags
+	flags.register(fs)
+	valuePath := fs.String("value", "",
+		"Value `file` (JSON if the extension is .json, text format otherwise; "+
+			"- for stdin, requiring an -input file; required)")
+	coveragePath := fs.String("coverage", "",
+		"write a coverage report for the Value to `file` "+
+			"(HTML if the extension is .html, text otherwise)")
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return err
+		}
+		return errUsage
+	}
+	if fs.NArg() != 0 || *valuePath == "" || flags.typeName == "" {
+		fs.Usage()
+		return errUsage
+	}
+	if *valuePath == "-" && flags.inputPath == "-" {
+		return errors.New("-value and -input cannot both be read from stdin; " +
+			"specify an -input file with -value -")
+	}
+	value, err := loadValue(*valuePath, stdin)
+	if err != nil {
+		return err
+	}
+	env, msg, evalArgs, err := flags.setup(stdin)
+	if err != nil {
+		return err
+	}
+	var coverage *protoeval.Coverage
+	if *coveragePath != "" {
+		coverage = protoeval.NewCoverage()
+		env.SetCoverage(coverage)
+	}
+	result, err := evalJSON(env, msg, value, evalArgs)
+	if coverage != nil {
+		if cerr := writeCoverage(*coveragePath, coverage, value,
+			*valuePath); cerr != nil {
+			return fmt.Errorf("write coverage report: %w", cerr)
+		}
+	}
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(stdout, "%s\n", result)
+	return err
+}
+
+// evalJSON evaluates msg according to value, and returns the result as
+// indented JSON.
+func evalJSON(
+	env *protoeval.Env, msg proto.Message, value *protoeval.Value,
+	args []interface{},
+) ([]byte, error) {
+	result, err := protoeval.EvalToProto(env, msg, value,
+		(&structpb.Value{}).ProtoReflect().Descriptor(), args...)
+	if err != nil {
+		return nil, err
+	}
+	return json.MarshalIndent(result.(*structpb.Value).AsInterface(), "", "  ")
+}
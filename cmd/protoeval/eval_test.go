@@ -0,0 +1,134 @@
+package main
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// testOrderJSON is a test input message of type clitest.Order.
+const testOrderJSON = `{
+  "id": "o-1",
+  "items": [
+    { "sku": "a", "qty": "2" },
+    { "sku": "b", "qty": "5" }
+  ]
+}`
+
+// TestEval tests the eval command.
+func TestEval(t *testing.T) {
+	input := writeTestFile(t, "order.json", testOrderJSON)
+	jsonValue := writeTestFile(t, "total.json", `
+    { "scope": ["items"], "sum": {
+      "value": { "program": { "code": "double(args[1].qty) * env.factor" } }
+    } }
+  `)
+	textValue := writeTestFile(t, "skus.txtpb", `
+    scope { values { string_value: "items" } }
+    transform {
+      value { program { code: "args[0] == 0 ? args[3] : args[1].sku" } }
+    }
+    args { basic_value { string_value: "ignored" } }
+  `)
+	for _, tc := range []struct {
+		name     string
+		stdin    string
+		args     []string
+		expected interface{}
+	}{
+		{"json value", "", []string{
+			"-descriptor-set", testDescriptorSet, "-type", "clitest.Order",
+			"-input", input, "-value", jsonValue, "-env", "factor=3",
+		}, 21.0},
+		{"text value, stdin, args", testOrderJSON, []string{
+			"-type", "clitest.Order", "-input-format", "json",
+			"-value", textValue, "-arg", `"first"`, "-arg", "null",
+		}, []interface{}{"first", "b"}},
+	} {
+		code, stdout, stderr := runTest(tc.stdin, append([]string{"eval"},
+			tc.args...)...)
+		if code != 0 {
+			t.Errorf("%s: exit code %d: %s", tc.name, code, stderr)
+			continue
+		}
+		var result interface{}
+		if err := json.Unmarshal([]byte(stdout), &result); err != nil {
+			t.Errorf("%s: decode output %s: %s", tc.name, stdout, err)
+			continue
+		}
+		if !reflect.DeepEqual(result, tc.expected) {
+			t.Errorf("%s: expected %v, got %v", tc.name, tc.expected, result)
+		}
+	}
+}
+
+// TestEvalErrors tests eval command errors.
+func TestEvalErrors(t *testing.T) {
+	input := writeTestFile(t, "order-errors.json", testOrderJSON)
+	value := writeTestFile(t, "fail.json", `
+    { "fail": { "code": "E1", "message": { "basic_value": "nope" } } }
+  `)
+	for _, tc := range []struct {
+		name     string
+		args     []string
+		code     int
+		contains string
+	}{
+		{"no value", []string{"-type", "clitest.Order"}, 2, "Usage"},
+		{"unknown type", []string{
+			"-type", "clitest.Nope", "-value", value, "-input", input,
+		}, 1, "clitest.Nope"},
+		{"bad env", []string{
+			"-type", "clitest.Order", "-value", value, "-input", input,
+			"-env", "x",
+		}, 1, "key=value"},
+		{"fail", []string{
+			"-type", "clitest.Order", "-value", value, "-input", input,
+		}, 1, "fail [E1]: nope"},
+		{"value and input from stdin", []string{
+			"-type", "clitest.Order", "-value", "-",
+		}, 1, "stdin"},
+	} {
+		code, _, stderr := runTest("", append([]string{"eval"}, tc.args...)...)
+		if code != tc.code {
+			t.Errorf("%s: expected exit code %d, got %d", tc.name, tc.code, code)
+		}
+		if !strings.Contains(stderr, tc.contains) {
+			t.Errorf("%s: expected %q in stderr, got %s", tc.name, tc.contains,
+				stderr)
+		}
+	}
+}
+
+// TestEvalCoverage tests coverage reports of the eval command.
+func TestEvalCoverage(t *testing.T) {
+	input := writeTestFile(t, "order.json", testOrderJSON)
+	value := writeTestFile(t, "empty.json", `
+    { "switch": { "cases": [ {
+      "case": { "program": { "code": "size(scope.value.items) == 0" } },
+      "then": { "basic_value": "empty" }
+    } ], "default": { "basic_value": "non-empty" } } }
+  `)
+	report := filepath.Join(testDir, "coverage.txt")
+	code, stdout, stderr := runTest("", "eval", "-descriptor-set",
+		testDescriptorSet, "-type", "clitest.Order", "-input", input,
+		"-value", value, "-coverage", report)
+	if code != 0 {
+		t.Fatalf("exit code %d: %s", code, stderr)
+	}
+	if strings.TrimSpace(stdout) != `"non-empty"` {
+		t.Errorf("unexpected result: %s", stdout)
+	}
+	data, err := ioutil.ReadFile(report)
+	if err != nil {
+		t.Fatalf("read coverage report: %s", err)
+	}
+	expected := "coverage: 3/4 Value nodes (75.0%)\n" +
+		"not evaluated: switch.cases[0].then (basic_value)\n"
+	if string(data) != expected {
+		t.Errorf("expected coverage report:\n%s\ngot:\n%s", expected, data)
+	}
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+
+	"github.com/TheCount/protoeval"
+)
+
+// runFmt runs the fmt command.
+func runFmt(args []string, stdin io.Reader, stdout, stderr io.Writer) error {
+	fs := flag.NewFlagSet("fmt", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: protoeval fmt [flags] [FILE...]")
+		fmt.Fprintln(stderr)
+		fmt.Fprintln(stderr, "Formats Value files in canonical JSON format. "+
+			"Files are decoded as JSON if")
+		fmt.Fprintln(stderr, "the extension is .json, and as text format "+
+			"otherwise. Without files, a JSON")
+		fmt.Fprintln(stderr, "Value is read from stdin.")
+		fmt.Fprintln(stderr)
+		fs.PrintDefaults()
+	}
+	write := fs.Bool("w", false,
+		"write the result to the (JSON) source file instead of stdout")
+	list := fs.Bool("l", false,
+		"list (JSON) files whose formatting differs from the canonical format")
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return err
+		}
+		return errUsage
+	}
+	paths := fs.Args()
+	if len(paths) == 0 {
+		if *write || *list {
+			fs.Usage()
+			return errUsage
+		}
+		paths = []string{"-"}
+	}
+	for _, path := range paths {
+		format := formatFromPath(path, formatText)
+		if path == "-" {
+			format = formatJSON
+		}
+		if (*write || *list) && format != formatJSON {
+			return fmt.Errorf("%s: -w and -l require JSON files", path)
+		}
+		data, err := readFile(path, stdin)
+		if err != nil {
+			return err
+		}
+		value := &protoeval.Value{}
+		if err = unmarshal(data, format, value); err != nil {
+			return fmt.Errorf("decode value file %s: %w", path, err)
+		}
+		formatted := protoeval.Format(value)
+		if *list && !bytes.Equal(data, formatted) {
+			fmt.Fprintln(stdout, path)
+		}
+		if *write {
+			if bytes.Equal(data, formatted) {
+				continue
+			}
+			info, err := os.Stat(path)
+			if err != nil {
+				return err
+			}
+			if err = ioutil.WriteFile(path, formatted, info.Mode()); err != nil {
+				return err
+			}
+			continue
+		}
+		if !*list {
+			if _, err = stdout.Write(formatted); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
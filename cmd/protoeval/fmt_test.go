@@ -0,0 +1,58 @@
+package main
+
+import (
+	"io/ioutil"
+	"strings"
+	"testing"
+)
+
+// testFormattedValue is a Value in canonical format.
+const testFormattedValue = `{
+  "scope": ["items", 0],
+  "program": {
+    "lines": ["scope.value.sku == 'a' &&", "  true"]
+  }
+}
+`
+
+// TestFmt tests the fmt command.
+func TestFmt(t *testing.T) {
+	text := writeTestFile(t, "fmt.txtpb", `
+    program { code: "scope.value.sku == 'a' &&\n  true" }
+    scope { values { string_value: "items" } values { number_value: 0 } }
+  `)
+	code, stdout, stderr := runTest("", "fmt", text)
+	if code != 0 {
+		t.Fatalf("exit code %d: %s", code, stderr)
+	}
+	if stdout != testFormattedValue {
+		t.Errorf("expected:\n%s\ngot:\n%s", testFormattedValue, stdout)
+	}
+	code, stdout, _ = runTest(`{"program":{"code":"true"}}`, "fmt")
+	if code != 0 || stdout != "{\n  \"program\": {\n    \"code\": \"true\"\n  }\n}\n" {
+		t.Errorf("unexpected output for stdin (exit code %d): %s", code, stdout)
+	}
+	formatted := writeTestFile(t, "formatted.json", testFormattedValue)
+	unformatted := writeTestFile(t, "unformatted.json",
+		`{"program":{"lines":["scope.value.sku == 'a' &&","  true"]},`+
+			`"scope":["items",0]}`)
+	code, stdout, _ = runTest("", "fmt", "-l", formatted, unformatted)
+	if code != 0 || stdout != unformatted+"\n" {
+		t.Errorf("unexpected list output (exit code %d): %s", code, stdout)
+	}
+	if code, _, stderr = runTest("", "fmt", "-w", unformatted); code != 0 {
+		t.Fatalf("write: exit code %d: %s", code, stderr)
+	}
+	data, err := ioutil.ReadFile(unformatted)
+	if err != nil {
+		t.Fatalf("read formatted file: %s", err)
+	}
+	if string(data) != testFormattedValue {
+		t.Errorf("expected written file:\n%s\ngot:\n%s", testFormattedValue, data)
+	}
+	code, _, stderr = runTest("", "fmt", "-w", text)
+	if code != 1 || !strings.Contains(stderr, "require JSON files") {
+		t.Errorf("expected error for rewriting text file (exit code %d): %s",
+			code, stderr)
+	}
+}
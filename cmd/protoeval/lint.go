@@ -0,0 +1,80 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/TheCount/protoeval"
+)
+
+// errLintFindings is returned by the lint command if there are findings.
+var errLintFindings = errors.New("lint findings reported")
+
+// parseSeverity parses the given severity name.
+func parseSeverity(name string) (protoeval.Severity, error) {
+	for _, s := range []protoeval.Severity{
+		protoeval.SeverityInfo, protoeval.SeverityWarning, protoeval.SeverityError,
+	} {
+		if s.String() == name {
+			return s, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown severity %q", name)
+}
+
+// runLint runs the lint command.
+func runLint(args []string, stdin io.Reader, stdout, stderr io.Writer) error {
+	fs := flag.NewFlagSet("lint", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: protoeval lint [flags] FILE...")
+		fmt.Fprintln(stderr)
+		fmt.Fprintln(stderr, "Checks Value files (JSON if the extension is "+
+			".json, text format otherwise)")
+		fmt.Fprintln(stderr, "for common mistakes. Exits with status 1 if "+
+			"there are findings.")
+		fmt.Fprintln(stderr)
+		fs.PrintDefaults()
+	}
+	severityName := fs.String("severity", "info",
+		"minimum `severity` of reported findings: info, warning, or error")
+	listRules := fs.Bool("rules", false, "list the lint rules and exit")
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return err
+		}
+		return errUsage
+	}
+	if *listRules {
+		for _, rule := range protoeval.LintRules() {
+			fmt.Fprintf(stdout, "%-20s %-8s %s\n", rule.ID, rule.Severity,
+				rule.Description)
+		}
+		return nil
+	}
+	minSeverity, err := parseSeverity(*severityName)
+	if err != nil || fs.NArg() == 0 {
+		fs.Usage()
+		return errUsage
+	}
+	found := false
+	for _, path := range fs.Args() {
+		value, err := loadValue(path, stdin)
+		if err != nil {
+			return err
+		}
+		for _, finding := range protoeval.Lint(value) {
+			if finding.Severity < minSeverity {
+				continue
+			}
+			found = true
+			fmt.Fprintf(stdout, "%s: %s\n", path, finding)
+		}
+	}
+	if found {
+		return errLintFindings
+	}
+	return nil
+}
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestLint tests the lint command.
+func TestLint(t *testing.T) {
+	clean := writeTestFile(t, "clean.json", `{ "int": 1 }`)
+	smelly := writeTestFile(t, "smelly.txtpb", `
+    seq { values { program { code: "dump(1)" } } }
+  `)
+	code, stdout, stderr := runTest("", "lint", clean)
+	if code != 0 || stdout != "" {
+		t.Errorf("expected no findings, got exit code %d: %s%s",
+			code, stdout, stderr)
+	}
+	code, stdout, _ = runTest("", "lint", clean, smelly)
+	expected := smelly + ": <root>: info: seq has a single element [seq-single]\n" +
+		smelly + ": seq.values[0]: warning: CEL program calls dump() [dump-call]\n"
+	if code != 1 || stdout != expected {
+		t.Errorf("expected exit code 1 and:\n%s\ngot exit code %d:\n%s",
+			expected, code, stdout)
+	}
+	code, stdout, _ = runTest("", "lint", "-severity", "warning", smelly)
+	if code != 1 || strings.Contains(stdout, "seq-single") {
+		t.Errorf("expected only warnings, got exit code %d: %s", code, stdout)
+	}
+	code, stdout, _ = runTest("", "lint", "-rules")
+	if code != 0 || !strings.Contains(stdout, "switch-unreachable") {
+		t.Errorf("expected rule list, got exit code %d: %s", code, stdout)
+	}
+	if code, _, _ = runTest("", "lint", "-severity", "nope", clean); code != 2 {
+		t.Errorf("expected exit code 2 for bad severity, got %d", code)
+	}
+}
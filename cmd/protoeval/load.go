@@ -0,0 +1,252 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/TheCount/protoeval"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// errUsage is returned by commands if they have been invoked incorrectly.
+// The command is responsible for reporting the details.
+var errUsage = errors.New("usage error")
+
+// Encoding formats for Values and messages.
+const (
+	formatJSON   = "json"
+	formatText   = "text"
+	formatBinary = "binary"
+)
+
+// stringList is a flag.Value collecting the values of a repeatable flag.
+type stringList []string
+
+// String implements flag.Value.String.
+func (l *stringList) String() string {
+	return strings.Join(*l, ",")
+}
+
+// Set implements flag.Value.Set.
+func (l *stringList) Set(value string) error {
+	*l = append(*l, value)
+	return nil
+}
+
+// formatFromPath infers the encoding format of the file at the given path
+// from its extension. If the extension is not recognised, def is returned.
+func formatFromPath(path, def string) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return formatJSON
+	case ".txtpb", ".textproto", ".textpb", ".pbtxt", ".prototxt":
+		return formatText
+	case ".pb", ".bin", ".binpb":
+		return formatBinary
+	default:
+		return def
+	}
+}
+
+// readFile reads the file at the given path. If path is "-", stdin is read
+// instead.
+func readFile(path string, stdin io.Reader) ([]byte, error) {
+	if path == "-" {
+		return ioutil.ReadAll(stdin)
+	}
+	return ioutil.ReadFile(path)
+}
+
+// unmarshal decodes data in the given format into msg.
+func unmarshal(data []byte, format string, msg proto.Message) error {
+	switch format {
+	case formatJSON:
+		return protojson.Unmarshal(data, msg)
+	case formatText:
+		return prototext.Unmarshal(data, msg)
+	case formatBinary:
+		return proto.Unmarshal(data, msg)
+	default:
+		return fmt.Errorf("unknown format %q", format)
+	}
+}
+
+// loadValue loads a Value from the file at the given path. The file is
+// decoded as JSON if its extension is .json, and as text format otherwise.
+func loadValue(path string, stdin io.Reader) (*protoeval.Value, error) {
+	data, err := readFile(path, stdin)
+	if err != nil {
+		return nil, err
+	}
+	value := &protoeval.Value{}
+	if err = unmarshal(data, formatFromPath(path, formatText), value); err != nil {
+		return nil, fmt.Errorf("decode value file %s: %w", path, err)
+	}
+	return value, nil
+}
+
+// loadMessage loads a message of the given type from the file at the given
+// path. If format is empty, it is inferred from the file extension, with
+// binary as fallback.
+func loadMessage(
+	path, format string, mt protoreflect.MessageType, stdin io.Reader,
+) (proto.Message, error) {
+	data, err := readFile(path, stdin)
+	if err != nil {
+		return nil, err
+	}
+	if format == "" {
+		format = formatFromPath(path, formatBinary)
+	}
+	msg := mt.New().Interface()
+	if err = unmarshal(data, format, msg); err != nil {
+		return nil, fmt.Errorf("decode message file %s: %w", path, err)
+	}
+	return msg, nil
+}
+
+// findMessageType finds the message type with the given full name.
+func findMessageType(name string) (protoreflect.MessageType, error) {
+	if name == "" {
+		return nil, errors.New("message type missing")
+	}
+	mt, err := protoregistry.GlobalTypes.FindMessageByName(
+		protoreflect.FullName(name))
+	if err != nil {
+		return nil, fmt.Errorf("find message type %s: %w", name, err)
+	}
+	return mt, nil
+}
+
+// registerDescriptorSet registers the files in the FileDescriptorSet in the
+// file at the given path, and all types defined therein, with the global
+// protobuf registries, so they are available to protoeval. Files already
+// registered (e. g., well-known types) are skipped. Dependencies not
+// contained in the set must already be registered.
+//
+// This must happen before the first protoeval environment is created.
+func registerDescriptorSet(path string) error {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	var set descriptorpb.FileDescriptorSet
+	if err = proto.Unmarshal(data, &set); err != nil {
+		return fmt.Errorf("decode descriptor set %s: %w", path, err)
+	}
+	files := make(map[string]*descriptorpb.FileDescriptorProto, len(set.File))
+	for _, fdp := range set.File {
+		files[fdp.GetName()] = fdp
+	}
+	var register func(name string) error
+	register = func(name string) error {
+		if _, err := protoregistry.GlobalFiles.FindFileByPath(name); err == nil {
+			return nil
+		}
+		fdp, ok := files[name]
+		if !ok {
+			return fmt.Errorf("file %s missing from descriptor set", name)
+		}
+		delete(files, name) // guards against import cycles
+		for _, dep := range fdp.Dependency {
+			if err := register(dep); err != nil {
+				return err
+			}
+		}
+		fd, err := protodesc.NewFile(fdp, protoregistry.GlobalFiles)
+		if err != nil {
+			return fmt.Errorf("file %s: %w", name, err)
+		}
+		if err = protoregistry.GlobalFiles.RegisterFile(fd); err != nil {
+			return fmt.Errorf("register file %s: %w", name, err)
+		}
+		return registerTypes(fd)
+	}
+	for _, fdp := range set.File {
+		if err := register(fdp.GetName()); err != nil {
+			return fmt.Errorf("descriptor set %s: %w", path, err)
+		}
+	}
+	return nil
+}
+
+// typeContainer is implemented by file and message descriptors.
+type typeContainer interface {
+	Enums() protoreflect.EnumDescriptors
+	Messages() protoreflect.MessageDescriptors
+	Extensions() protoreflect.ExtensionDescriptors
+}
+
+// registerTypes registers the types declared in the given file or message
+// with the global type registry, using dynamic messages.
+func registerTypes(tc typeContainer) error {
+	for i := 0; i < tc.Enums().Len(); i++ {
+		et := dynamicpb.NewEnumType(tc.Enums().Get(i))
+		if err := protoregistry.GlobalTypes.RegisterEnum(et); err != nil {
+			return err
+		}
+	}
+	for i := 0; i < tc.Messages().Len(); i++ {
+		md := tc.Messages().Get(i)
+		if !md.IsMapEntry() {
+			mt := dynamicpb.NewMessageType(md)
+			if err := protoregistry.GlobalTypes.RegisterMessage(mt); err != nil {
+				return err
+			}
+		}
+		if err := registerTypes(md); err != nil {
+			return err
+		}
+	}
+	for i := 0; i < tc.Extensions().Len(); i++ {
+		xt := dynamicpb.NewExtensionType(tc.Extensions().Get(i))
+		if err := protoregistry.GlobalTypes.RegisterExtension(xt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// parseJSONValue parses the given JSON text into a Go value suitable as
+// protoeval argument or environment value. JSON numbers become float64
+// values.
+func parseJSONValue(text string) (interface{}, error) {
+	var value structpb.Value
+	if err := protojson.Unmarshal([]byte(text), &value); err != nil {
+		return nil, fmt.Errorf("parse JSON value %q: %w", text, err)
+	}
+	return value.AsInterface(), nil
+}
+
+// newEnv creates a new protoeval environment with the given key=value
+// environment settings. The values are JSON encoded.
+func newEnv(settings []string) (*protoeval.Env, error) {
+	env := protoeval.NewEnv()
+	for _, setting := range settings {
+		eq := strings.IndexByte(setting, '=')
+		if eq <= 0 {
+			return nil, fmt.Errorf("environment setting %q not of the form key=value",
+				setting)
+		}
+		value, err := parseJSONValue(setting[eq+1:])
+		if err != nil {
+			return nil, fmt.Errorf("environment setting %s: %w", setting[:eq], err)
+		}
+		if err = env.Set(setting[:eq], value); err != nil {
+			return nil, fmt.Errorf("environment setting %s: %w", setting[:eq], err)
+		}
+	}
+	return env, nil
+}
@@ -0,0 +1,121 @@
+// Command protoeval evaluates protoeval rules from the command line.
+//
+// Usage:
+//
+//	protoeval <command> [flags]
+//
+// The eval command evaluates a Value against a message. The repl command
+// explores a message interactively with CEL expressions and Values. The test
+// command runs test suites for Values (see package protoevaltest). The fmt
+// command formats Value files in a canonical JSON format, and the lint command
+// checks them for common mistakes. The serve command provides evaluations to
+// other programs as a gRPC service and over HTTP with JSON bodies (see package
+// evalservice).
+//
+// Run "protoeval help" for a list of commands, and
+// "protoeval <command> -help" for the flags of a command.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+)
+
+// command describes a protoeval subcommand.
+type command struct {
+	// name is the name of the command.
+	name string
+
+	// summary is a one-line description of the command.
+	summary string
+
+	// run runs the command with the given command line arguments (excluding
+	// the command name).
+	run func(args []string, stdin io.Reader, stdout, stderr io.Writer) error
+}
+
+// commands returns the list of available commands.
+func commands() []command {
+	return []command{
+		{
+			name:    "eval",
+			summary: "evaluate a Value against a message",
+			run:     runEval,
+		},
+		{
+			name:    "repl",
+			summary: "explore a message interactively",
+			run:     runREPL,
+		},
+		{
+			name:    "test",
+			summary: "run test suites for Values",
+			run:     runTestCommand,
+		},
+		{
+			name:    "fmt",
+			summary: "format Value files canonically",
+			run:     runFmt,
+		},
+		{
+			name:    "lint",
+			summary: "check Value files for common mistakes",
+			run:     runLint,
+		},
+		{
+			name:    "serve",
+			summary: "serve evaluations over gRPC and HTTP",
+			run:     runServe,
+		},
+	}
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+// run runs protoeval with the given command line arguments (excluding the
+// program name) and returns the exit code.
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	if len(args) == 0 || args[0] == "help" || args[0] == "-h" ||
+		args[0] == "-help" || args[0] == "--help" {
+		usage(stderr)
+		if len(args) == 0 {
+			return 2
+		}
+		return 0
+	}
+	for _, cmd := range commands() {
+		if cmd.name != args[0] {
+			continue
+		}
+		err := cmd.run(args[1:], stdin, stdout, stderr)
+		switch {
+		case err == nil:
+			return 0
+		case errors.Is(err, flag.ErrHelp):
+			return 0
+		case errors.Is(err, errUsage):
+			return 2
+		default:
+			fmt.Fprintf(stderr, "protoeval %s: %s\n", cmd.name, err)
+			return 1
+		}
+	}
+	fmt.Fprintf(stderr, "protoeval: unknown command %q\n", args[0])
+	usage(stderr)
+	return 2
+}
+
+// usage prints the general usage message to w.
+func usage(w io.Writer) {
+	fmt.Fprintln(w, "Usage: protoeval <command> [flags]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Commands:")
+	for _, cmd := range commands() {
+		fmt.Fprintf(w, "  %-8s %s\n", cmd.name, cmd.summary)
+	}
+}
@@ -0,0 +1,144 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// testDir is a temporary directory holding test files.
+var testDir string
+
+// testDescriptorSet is the path of a descriptor set file describing the
+// message types for testing.
+var testDescriptorSet string
+
+// testFileDescriptor describes the message types for testing:
+//
+//	syntax = "proto3";
+//	package clitest;
+//	message Order {
+//	  string id = 1;
+//	  repeated Item items = 2;
+//	}
+//	message Item {
+//	  string sku = 1;
+//	  int64 qty = 2;
+//	}
+var testFileDescriptor = &descriptorpb.FileDescriptorProto{
+	Name:    proto.String("clitest/order.proto"),
+	Package: proto.String("clitest"),
+	Syntax:  proto.String("proto3"),
+	MessageType: []*descriptorpb.DescriptorProto{
+		{
+			Name: proto.String("Order"),
+			Field: []*descriptorpb.FieldDescriptorProto{
+				{
+					Name:     proto.String("id"),
+					JsonName: proto.String("id"),
+					Number:   proto.Int32(1),
+					Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+					Type:     descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum(),
+				},
+				{
+					Name:     proto.String("items"),
+					JsonName: proto.String("items"),
+					Number:   proto.Int32(2),
+					Label:    descriptorpb.FieldDescriptorProto_LABEL_REPEATED.Enum(),
+					Type:     descriptorpb.FieldDescriptorProto_TYPE_MESSAGE.Enum(),
+					TypeName: proto.String(".clitest.Item"),
+				},
+			},
+		},
+		{
+			Name: proto.String("Item"),
+			Field: []*descriptorpb.FieldDescriptorProto{
+				{
+					Name:     proto.String("sku"),
+					JsonName: proto.String("sku"),
+					Number:   proto.Int32(1),
+					Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+					Type:     descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum(),
+				},
+				{
+					Name:     proto.String("qty"),
+					JsonName: proto.String("qty"),
+					Number:   proto.Int32(2),
+					Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+					Type:     descriptorpb.FieldDescriptorProto_TYPE_INT64.Enum(),
+				},
+			},
+		},
+	},
+}
+
+// TestMain creates the test files and registers the test message types
+// before any test can initialise protoeval.
+func TestMain(m *testing.M) {
+	os.Exit(func() int {
+		var err error
+		testDir, err = ioutil.TempDir("", "protoeval-cmd-test")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "create test directory: %s\n", err)
+			return 1
+		}
+		defer os.RemoveAll(testDir)
+		data, err := proto.Marshal(&descriptorpb.FileDescriptorSet{
+			File: []*descriptorpb.FileDescriptorProto{testFileDescriptor},
+		})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "marshal descriptor set: %s\n", err)
+			return 1
+		}
+		testDescriptorSet = filepath.Join(testDir, "order.pb")
+		if err = ioutil.WriteFile(testDescriptorSet, data, 0644); err != nil {
+			fmt.Fprintf(os.Stderr, "write descriptor set: %s\n", err)
+			return 1
+		}
+		if err = registerDescriptorSet(testDescriptorSet); err != nil {
+			fmt.Fprintf(os.Stderr, "register descriptor set: %s\n", err)
+			return 1
+		}
+		return m.Run()
+	}())
+}
+
+// writeTestFile writes a test file with the given name and contents, and
+// returns its path.
+func writeTestFile(t *testing.T, name, contents string) string {
+	t.Helper()
+	path := filepath.Join(testDir, name)
+	if err := ioutil.WriteFile(path, []byte(contents), 0644); err != nil {
+		t.Fatalf("write test file %s: %s", name, err)
+	}
+	return path
+}
+
+// runTest runs protoeval with the given arguments and stdin contents, and
+// returns the exit code, stdout and stderr.
+func runTest(stdin string, args ...string) (int, string, string) {
+	var stdout, stderr bytes.Buffer
+	code := run(args, strings.NewReader(stdin), &stdout, &stderr)
+	return code, stdout.String(), stderr.String()
+}
+
+// TestUsage tests the general usage message.
+func TestUsage(t *testing.T) {
+	code, _, stderr := runTest("")
+	if code != 2 {
+		t.Errorf("expected exit code 2, got %d", code)
+	}
+	if !strings.Contains(stderr, "eval") {
+		t.Errorf("usage does not mention eval: %s", stderr)
+	}
+	if code, _, _ = runTest("", "nope"); code != 2 {
+		t.Errorf("expected exit code 2 for unknown command, got %d", code)
+	}
+}
@@ -0,0 +1,326 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/TheCount/protoeval"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// replHelp is the help text of the REPL.
+const replHelp = `Enter a CEL expression, or a Value in JSON format (starting with "{").
+Both are evaluated in the current scope. Commands:
+  :cd STEP...     shift the scope by the given steps; a step is a JSON value
+                  (e. g., 0, true, null, or "field name") or a bare word
+  :cd [STEP,...]  shift the scope by the given JSON list of steps
+  :up             shift the scope to the parent scope; after a :cd with a
+                  wildcard (null) or multi-select step, this is the scope
+                  before the :cd
+  :scope          show the current scope path and value
+  :args           show the evaluation arguments
+  :env            show the environment values
+  :help           show this help
+  :quit           quit`
+
+// repl is the state of a protoeval REPL session.
+type repl struct {
+	// env is the evaluation environment, kept between inputs.
+	env *protoeval.Env
+
+	// msg is the message being explored.
+	msg proto.Message
+
+	// args are the evaluation arguments.
+	args []interface{}
+
+	// shifts are the scope shifts from the message to the current scope, in
+	// order. Each shift is a scope selection path as in Value.Scope, or nil
+	// for a shift to the parent scope.
+	shifts [][]*structpb.Value
+
+	// path is the path of the current scope for display. Each element is a
+	// group of steps :up returns from at once: a single step, or all steps of
+	// a :cd which fanned out, as the parent scope of a fanned out scope is the
+	// scope before the shift.
+	path [][]*structpb.Value
+
+	// out receives the REPL output.
+	out io.Writer
+}
+
+// runREPL runs the repl command.
+func runREPL(args []string, stdin io.Reader, stdout, stderr io.Writer) error {
+	fs := flag.NewFlagSet("repl", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: protoeval repl -input FILE -type NAME [flags]")
+		fmt.Fprintln(stderr)
+		fmt.Fprintln(stderr, "Explores a message interactively with CEL "+
+			"expressions and Values.")
+		fmt.Fprintln(stderr)
+		fs.PrintDefaults()
+	}
+	var flags evalFlags
+	flags.register(fs)
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return err
+		}
+		return errUsage
+	}
+	if fs.NArg() != 0 || flags.typeName == "" || flags.inputPath == "-" {
+		fs.Usage()
+		return errUsage
+	}
+	env, msg, evalArgs, err := flags.setup(stdin)
+	if err != nil {
+		return err
+	}
+	r := &repl{
+		env:  env,
+		msg:  msg,
+		args: evalArgs,
+		out:  stdout,
+	}
+	fmt.Fprintln(stdout, `Type ":help" for help.`)
+	scanner := bufio.NewScanner(stdin)
+	for {
+		fmt.Fprintf(stdout, "%s> ", r.prompt())
+		if !scanner.Scan() {
+			fmt.Fprintln(stdout)
+			return scanner.Err()
+		}
+		if r.handle(strings.TrimSpace(scanner.Text())) {
+			return nil
+		}
+	}
+}
+
+// prompt returns the prompt for the current scope.
+func (r *repl) prompt() string {
+	var sb strings.Builder
+	sb.WriteString("protoeval:")
+	for _, steps := range r.path {
+		for _, step := range steps {
+			sb.WriteByte('/')
+			sb.WriteString(formatStep(step))
+		}
+	}
+	if len(r.path) == 0 {
+		sb.WriteByte('/')
+	}
+	return sb.String()
+}
+
+// formatStep formats the given scope step for display.
+func formatStep(step *structpb.Value) string {
+	switch x := step.Kind.(type) {
+	case *structpb.Value_StringValue:
+		return x.StringValue
+	case *structpb.Value_NullValue:
+		return "*"
+	default:
+		data, err := protojson.Marshal(step)
+		if err != nil {
+			return "?"
+		}
+		return string(data)
+	}
+}
+
+// handle handles a line of input. It reports whether the session should end.
+func (r *repl) handle(line string) bool {
+	var err error
+	switch {
+	case line == "":
+	case line == ":quit" || line == ":q":
+		return true
+	case line == ":help":
+		fmt.Fprintln(r.out, replHelp)
+	case line == ":cd" || strings.HasPrefix(line, ":cd "):
+		err = r.cd(strings.TrimSpace(strings.TrimPrefix(line, ":cd")))
+	case line == ":up":
+		err = r.up()
+	case line == ":scope":
+		fmt.Fprintln(r.out, r.prompt())
+		err = r.eval(&protoeval.Value{})
+	case line == ":args":
+		err = r.showArgs()
+	case line == ":env":
+		err = r.showEnv()
+	case strings.HasPrefix(line, ":"):
+		err = fmt.Errorf("unknown command %s", strings.Fields(line)[0])
+	case strings.HasPrefix(line, "{"):
+		var value protoeval.Value
+		if err = protojson.Unmarshal([]byte(line), &value); err == nil {
+			err = r.eval(&value)
+		}
+	default:
+		err = r.eval(&protoeval.Value{
+			Value: &protoeval.Value_Program_{
+				Program: &protoeval.Value_Program{Code: line},
+			},
+		})
+	}
+	if err != nil {
+		fmt.Fprintf(r.out, "error: %s\n", err)
+	}
+	return false
+}
+
+// inScope returns a Value which evaluates value in the scope reached by the
+// given shifts.
+func inScope(
+	shifts [][]*structpb.Value, value *protoeval.Value,
+) *protoeval.Value {
+	for i := len(shifts) - 1; i >= 0; i-- {
+		if shifts[i] == nil {
+			value = &protoeval.Value{
+				Value: &protoeval.Value_Parent{Parent: value},
+			}
+			continue
+		}
+		value = &protoeval.Value{
+			Scope: &structpb.ListValue{Values: shifts[i]},
+			Value: &protoeval.Value_Seq{
+				Seq: &protoeval.Value_ValueList{
+					Values: []*protoeval.Value{value},
+				},
+			},
+		}
+	}
+	return value
+}
+
+// eval evaluates the given value in the current scope and prints the result.
+func (r *repl) eval(value *protoeval.Value) error {
+	result, err := evalJSON(r.env, r.msg, inScope(r.shifts, value), r.args)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(r.out, "%s\n", result)
+	return nil
+}
+
+// shift checks that the current scope can be shifted as given (see
+// repl.shifts), and if so, performs the shift.
+func (r *repl) shift(steps []*structpb.Value) error {
+	shifts := append(r.shifts[:len(r.shifts):len(r.shifts)], steps)
+	if _, err := protoeval.Eval(r.env, r.msg,
+		inScope(shifts, &protoeval.Value{}), r.args...); err != nil {
+		return err
+	}
+	r.shifts = shifts
+	return nil
+}
+
+// cd shifts the current scope by the steps in the given argument.
+func (r *repl) cd(arg string) error {
+	var steps []*structpb.Value
+	if strings.HasPrefix(arg, "[") {
+		var list structpb.ListValue
+		if err := protojson.Unmarshal([]byte(arg), &list); err != nil {
+			return fmt.Errorf("parse step list: %w", err)
+		}
+		steps = list.Values
+	} else {
+		for _, word := range strings.Fields(arg) {
+			steps = append(steps, parseStep(word))
+		}
+	}
+	if len(steps) == 0 {
+		return errors.New("no steps given")
+	}
+	if err := r.shift(steps); err != nil {
+		return err
+	}
+	if r.fanned() || fansOut(steps) {
+		r.path = append(r.path, steps)
+		return nil
+	}
+	for _, step := range steps {
+		r.path = append(r.path, []*structpb.Value{step})
+	}
+	return nil
+}
+
+// up shifts the current scope to its parent scope.
+func (r *repl) up() error {
+	if err := r.shift(nil); err != nil {
+		return err
+	}
+	r.path = r.path[:len(r.path)-1]
+	return nil
+}
+
+// fanned reports whether the current scope is the result of a wildcard or
+// multi-select step. Shifting such a scope fans out again.
+func (r *repl) fanned() bool {
+	for _, steps := range r.path {
+		if fansOut(steps) {
+			return true
+		}
+	}
+	return false
+}
+
+// fansOut reports whether the given steps contain a wildcard or multi-select
+// step.
+func fansOut(steps []*structpb.Value) bool {
+	for _, step := range steps {
+		switch step.Kind.(type) {
+		case *structpb.Value_NullValue, *structpb.Value_ListValue:
+			return true
+		}
+	}
+	return false
+}
+
+// parseStep parses a single scope step. A word which is not valid JSON is
+// taken as a string step.
+func parseStep(word string) *structpb.Value {
+	var step structpb.Value
+	if err := protojson.Unmarshal([]byte(word), &step); err == nil {
+		return &step
+	}
+	return structpb.NewStringValue(word)
+}
+
+// showArgs prints the evaluation arguments.
+func (r *repl) showArgs() error {
+	for i, arg := range r.args {
+		data, err := json.Marshal(arg)
+		if err != nil {
+			return fmt.Errorf("arg %d: %w", i, err)
+		}
+		fmt.Fprintf(r.out, "%d: %s\n", i, data)
+	}
+	return nil
+}
+
+// showEnv prints the environment values.
+func (r *repl) showEnv() error {
+	for _, key := range r.env.Keys() {
+		result, err := evalJSON(r.env, r.msg, &protoeval.Value{
+			Value: &protoeval.Value_Program_{
+				Program: &protoeval.Value_Program{
+					Code: "env[" + strconv.Quote(key) + "]",
+				},
+			},
+		}, nil)
+		if err != nil {
+			return fmt.Errorf("env %s: %w", key, err)
+		}
+		fmt.Fprintf(r.out, "%s = %s\n", key, result)
+	}
+	return nil
+}
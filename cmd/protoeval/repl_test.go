@@ -0,0 +1,88 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestREPL tests a REPL session.
+func TestREPL(t *testing.T) {
+	input := writeTestFile(t, "order-repl.json", testOrderJSON)
+	code, stdout, stderr := runTest(strings.Join([]string{
+		`scope.value.id`,
+		`:cd items 1`,
+		`scope.value.sku`,
+		`:up`,
+		`:cd ["nope"]`,
+		`{ "count": {} }`,
+		`:up`,
+		`:up`,
+		`(args[0] + 1.0).store('x')`,
+		`:env`,
+		`:args`,
+		`:bogus`,
+		`:quit`,
+		`"not reached"`,
+	}, "\n"), "repl", "-type", "clitest.Order", "-input", input,
+		"-arg", "41", "-env", `greeting="hi"`)
+	if code != 0 {
+		t.Fatalf("exit code %d: %s", code, stderr)
+	}
+	for _, expected := range []string{
+		"protoeval:/> \"o-1\"\n",
+		"protoeval:/items/1> \"b\"\n",
+		"protoeval:/items> error: ",
+		"protoeval:/items> 2\n",
+		"protoeval:/> error: already at the root scope\n",
+		"protoeval:/> 42\n",
+		"greeting = \"hi\"\nx = 42\n",
+		"0: 41\n",
+		"error: unknown command :bogus\n",
+	} {
+		if !strings.Contains(stdout, expected) {
+			t.Errorf("expected %q in output:\n%s", expected, stdout)
+		}
+	}
+	if strings.Contains(stdout, "not reached") {
+		t.Error("input after :quit processed")
+	}
+}
+
+// TestREPLUp tests :up after wildcard steps. The parent of a fanned out
+// scope is the scope before the shift.
+func TestREPLUp(t *testing.T) {
+	input := writeTestFile(t, "order-repl-up.json", testOrderJSON)
+	code, stdout, stderr := runTest(strings.Join([]string{
+		`:cd items null sku`,
+		`scope.list`,
+		`:up`,
+		`scope.value.id`,
+		`:cd items`,
+		`:cd null`,
+		`:cd sku`,
+		`:up`,
+		`has(scope.field_descriptor)`,
+		`:up`,
+		`has(scope.field_descriptor)`,
+	}, "\n"), "repl", "-type", "clitest.Order", "-input", input)
+	if code != 0 {
+		t.Fatalf("exit code %d: %s", code, stderr)
+	}
+	for _, expected := range []string{
+		"protoeval:/items/*/sku> [\n  \"a\",\n  \"b\"\n]\n",
+		"protoeval:/> \"o-1\"\n",
+		"protoeval:/items/*> false\n",
+		"protoeval:/items> true\n",
+	} {
+		if !strings.Contains(stdout, expected) {
+			t.Errorf("expected %q in output:\n%s", expected, stdout)
+		}
+	}
+}
+
+// TestREPLUsage tests REPL invocation errors.
+func TestREPLUsage(t *testing.T) {
+	if code, _, _ := runTest("", "repl", "-type", "clitest.Order"); code != 2 {
+		t.Errorf("expected exit code 2 without input file, got %d", code)
+	}
+}
@@ -0,0 +1,164 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"github.com/TheCount/protoeval/evalservice"
+	"google.golang.org/grpc"
+)
+
+// parseListenAddress parses a listen address of the form unix:PATH or
+// tcp:HOST:PORT into network and address. An address without network prefix
+// is a Unix socket path.
+func parseListenAddress(listen string) (network, address string) {
+	for _, network := range []string{"unix", "tcp"} {
+		if strings.HasPrefix(listen, network+":") {
+			return network, listen[len(network)+1:]
+		}
+	}
+	return "unix", listen
+}
+
+// loadRules loads the rules given as name=FILE settings into server.
+func loadRules(
+	server *evalservice.Server, settings []string, stdin io.Reader,
+) error {
+	for _, setting := range settings {
+		eq := strings.IndexByte(setting, '=')
+		if eq <= 0 {
+			return fmt.Errorf("rule %q not of the form name=file", setting)
+		}
+		value, err := loadValue(setting[eq+1:], stdin)
+		if err != nil {
+			return fmt.Errorf("rule %s: %w", setting[:eq], err)
+		}
+		server.SetRule(setting[:eq], value)
+	}
+	return nil
+}
+
+// runServe runs the serve command.
+func runServe(args []string, stdin io.Reader, stdout, stderr io.Writer) error {
+	fs := flag.NewFlagSet("serve", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		fmt.Fprintln(stderr,
+			"Usage: protoeval serve [-listen ADDRESS] [-http ADDRESS] [flags]")
+		fmt.Fprintln(stderr)
+		fmt.Fprintln(stderr, "Serves the EvalService gRPC service "+
+			"(see protoeval/eval_service.proto) and/or HTTP/JSON evaluations "+
+			"(see package evalservice) until interrupted.")
+		fmt.Fprintln(stderr)
+		fs.PrintDefaults()
+	}
+	var descriptorSets, env, rules stringList
+	fs.Var(&descriptorSets, "descriptor-set",
+		"`file` containing a FileDescriptorSet with the message types "+
+			"(repeatable)")
+	fs.Var(&env, "env",
+		"environment value as `key=value`, with JSON encoded value (repeatable)")
+	fs.Var(&rules, "rule",
+		"preload the Value in the given file as rule, as `name=file` "+
+			"(repeatable)")
+	listen := fs.String("listen", "",
+		"`address` to serve gRPC on: a Unix socket path, unix:PATH, or "+
+			"tcp:HOST:PORT")
+	httpAddress := fs.String("http", "",
+		"TCP `address` to serve HTTP/JSON evaluations on, as HOST:PORT")
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return err
+		}
+		return errUsage
+	}
+	if fs.NArg() != 0 || (*listen == "" && *httpAddress == "") {
+		fs.Usage()
+		return errUsage
+	}
+	for _, path := range descriptorSets {
+		if err := registerDescriptorSet(path); err != nil {
+			return err
+		}
+	}
+	baseEnv, err := newEnv(env)
+	if err != nil {
+		return err
+	}
+	server := evalservice.NewServer(baseEnv)
+	if err = loadRules(server, rules, stdin); err != nil {
+		return err
+	}
+	var grpcListener, httpListener net.Listener
+	if *listen != "" {
+		network, address := parseListenAddress(*listen)
+		if grpcListener, err = net.Listen(network, address); err != nil {
+			return err
+		}
+		fmt.Fprintf(stderr, "serving gRPC on %s:%s\n", network,
+			grpcListener.Addr())
+	}
+	if *httpAddress != "" {
+		if httpListener, err = net.Listen("tcp", *httpAddress); err != nil {
+			if grpcListener != nil {
+				grpcListener.Close()
+			}
+			return err
+		}
+		fmt.Fprintf(stderr, "serving HTTP on %s\n", httpListener.Addr())
+	}
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(signals)
+	errs := make(chan error, 2)
+	running := 0
+	var grpcServer *grpc.Server
+	if grpcListener != nil {
+		grpcServer = grpc.NewServer()
+		evalservice.RegisterEvalServiceServer(grpcServer, server)
+		running++
+		go func() {
+			errs <- grpcServer.Serve(grpcListener)
+		}()
+	}
+	var httpServer *http.Server
+	if httpListener != nil {
+		httpServer = &http.Server{
+			Handler: evalservice.NewHTTPHandler(server),
+		}
+		running++
+		go func() {
+			err := httpServer.Serve(httpListener)
+			if errors.Is(err, http.ErrServerClosed) {
+				err = nil
+			}
+			errs <- err
+		}()
+	}
+	select {
+	case <-signals:
+	case err = <-errs:
+		running--
+	}
+	if grpcServer != nil {
+		grpcServer.GracefulStop()
+	}
+	if httpServer != nil {
+		httpServer.Shutdown(context.Background())
+	}
+	for ; running > 0; running-- {
+		if e := <-errs; err == nil {
+			err = e
+		}
+	}
+	return err
+}
@@ -0,0 +1,138 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/TheCount/protoeval"
+	"github.com/TheCount/protoeval/evalservice"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// freeTCPAddress returns a TCP address on the loopback interface which is
+// currently not in use.
+func freeTCPAddress(t *testing.T) string {
+	t.Helper()
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer lis.Close()
+	return lis.Addr().String()
+}
+
+// TestServe tests the serve command.
+func TestServe(t *testing.T) {
+	socket := filepath.Join(testDir, "serve.sock")
+	httpAddress := freeTCPAddress(t)
+	rulePath := writeTestFile(t, "serve_rule.json",
+		`{ "program": { "code": "size(scope.value.items)" } }`)
+	var stderr bytes.Buffer
+	done := make(chan error, 1)
+	go func() {
+		done <- runServe([]string{"-listen", "unix:" + socket,
+			"-http", httpAddress, "-env", `answer=42`,
+			"-rule", "items=" + rulePath}, nil, nil, &stderr)
+	}()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	conn, err := grpc.DialContext(ctx, "unix:"+socket, grpc.WithInsecure(),
+		grpc.WithBlock())
+	if err != nil {
+		t.Fatalf("dial: %s", err)
+	}
+	defer conn.Close()
+	mt, err := findMessageType("clitest.Order")
+	if err != nil {
+		t.Fatal(err)
+	}
+	msg, err := anypb.New(mt.New().Interface())
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := evalservice.NewEvalServiceClient(conn).Eval(ctx,
+		&evalservice.EvalRequest{
+			Value: &protoeval.Value{
+				Value: &protoeval.Value_Program_{
+					Program: &protoeval.Value_Program{
+						Code: "double(size(scope.value.items)) + env['answer']",
+					},
+				},
+			},
+			Message: msg,
+		})
+	if err != nil {
+		t.Fatalf("eval: %s", err)
+	}
+	if resp.GetResult().GetNumberValue() != 42 {
+		t.Errorf("expected result 42, got %s", resp)
+	}
+	httpResp, err := http.Post("http://"+httpAddress, "application/json",
+		strings.NewReader(`{
+			"rule": "items",
+			"type": "clitest.Order",
+			"message": { "items": [ { "sku": "a" } ] }
+		}`))
+	if err != nil {
+		t.Fatalf("HTTP eval: %s", err)
+	}
+	body, err := ioutil.ReadAll(httpResp.Body)
+	httpResp.Body.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var result map[string]interface{}
+	if err = json.Unmarshal(body, &result); err != nil {
+		t.Fatalf("decode HTTP response %s: %s", body, err)
+	}
+	if result["result"] != 1.0 {
+		t.Errorf("expected HTTP result 1, got %s", body)
+	}
+	if err = syscall.Kill(syscall.Getpid(), syscall.SIGINT); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case err = <-done:
+		if err != nil {
+			t.Errorf("serve: %s", err)
+		}
+	case <-ctx.Done():
+		t.Fatal("server did not stop")
+	}
+	for _, line := range []string{
+		"serving gRPC on unix:" + socket,
+		"serving HTTP on " + httpAddress,
+	} {
+		if !strings.Contains(stderr.String(), line) {
+			t.Errorf("listen address not reported: %s", stderr.String())
+		}
+	}
+}
+
+// TestServeUsage tests usage errors of the serve command.
+func TestServeUsage(t *testing.T) {
+	for _, args := range [][]string{
+		{"serve"},
+		{"serve", "-listen", "a.sock", "extra"},
+	} {
+		if code, _, _ := runTest("", args...); code != 2 {
+			t.Errorf("%v: expected exit code 2, got %d", args, code)
+		}
+	}
+	code, _, stderr := runTest("", "serve", "-http", "127.0.0.1:0",
+		"-rule", "nofile")
+	if code != 1 || !strings.Contains(stderr, "name=file") {
+		t.Errorf("expected rule error, got exit code %d (stderr: %s)",
+			code, stderr)
+	}
+}
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/TheCount/protoeval"
+	"github.com/TheCount/protoeval/protoevaltest"
+)
+
+// errTestsFailed is returned by the test command if a test case failed.
+var errTestsFailed = errors.New("tests failed")
+
+// runTestCommand runs the test command.
+func runTestCommand(
+	args []string, stdin io.Reader, stdout, stderr io.Writer,
+) error {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: protoeval test [flags] SUITE...")
+		fmt.Fprintln(stderr)
+		fmt.Fprintln(stderr, "Runs the test cases in the given suite files "+
+			"(JSON if the extension is .json, text format otherwise).")
+		fmt.Fprintln(stderr)
+		fs.PrintDefaults()
+	}
+	var descriptorSets stringList
+	fs.Var(&descriptorSets, "descriptor-set",
+		"`file` containing a FileDescriptorSet with the message types "+
+			"(repeatable)")
+	update := fs.Bool("update", false,
+		"replace the expected outcomes with the actual outcomes")
+	verbose := fs.Bool("v", false, "also report passing test cases")
+	coveragePath := fs.String("coverage", "",
+		"write a coverage report for the suite Value to `file` "+
+			"(HTML if the extension is .html, text otherwise; requires a single "+
+			"suite)")
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return err
+		}
+		return errUsage
+	}
+	if fs.NArg() == 0 ||
+		(*coveragePath != "" && (*update || fs.NArg() != 1)) {
+		fs.Usage()
+		return errUsage
+	}
+	for _, path := range descriptorSets {
+		if err := registerDescriptorSet(path); err != nil {
+			return err
+		}
+	}
+	var coverage *protoeval.Coverage
+	var coveredValue *protoeval.Value
+	if *coveragePath != "" {
+		coverage = protoeval.NewCoverage()
+	}
+	failed := false
+	for _, path := range fs.Args() {
+		suite, err := protoevaltest.LoadSuite(path)
+		if err != nil {
+			return err
+		}
+		if *update {
+			if err = protoevaltest.UpdateSuite(suite); err != nil {
+				return fmt.Errorf("suite %s: %w", path, err)
+			}
+			if err = protoevaltest.WriteSuite(path, suite); err != nil {
+				return fmt.Errorf("suite %s: %w", path, err)
+			}
+			fmt.Fprintf(stdout, "updated %s (%d cases)\n", path, len(suite.Cases))
+			continue
+		}
+		results, err := protoevaltest.RunSuiteCoverage(suite, coverage)
+		if err != nil {
+			return fmt.Errorf("suite %s: %w", path, err)
+		}
+		coveredValue = suite.Value
+		for _, result := range results {
+			if result.Err == nil {
+				if *verbose {
+					fmt.Fprintf(stdout, "PASS %s/%s\n", path, result.Name)
+				}
+				continue
+			}
+			failed = true
+			fmt.Fprintf(stdout, "FAIL %s/%s\n    %s\n", path, result.Name,
+				strings.ReplaceAll(result.Err.Error(), "\n", "\n    "))
+		}
+	}
+	if coverage != nil {
+		if err := writeCoverage(*coveragePath, coverage, coveredValue,
+			fs.Arg(0)); err != nil {
+			return fmt.Errorf("write coverage report: %w", err)
+		}
+	}
+	if failed {
+		return errTestsFailed
+	}
+	if !*update {
+		fmt.Fprintln(stdout, "PASS")
+	}
+	return nil
+}
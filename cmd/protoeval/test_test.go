@@ -0,0 +1,87 @@
+package main
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// testSuiteJSON is a test suite for orders with a passing and a failing test
+// case.
+const testSuiteJSON = `{
+  "value": { "program": { "code": "size(scope.value.items)" } },
+  "cases": [
+    {
+      "name": "two_items",
+      "input": {
+        "@type": "type.googleapis.com/clitest.Order",
+        "items": [ { "sku": "a" }, { "sku": "b" } ]
+      },
+      "result": 2
+    },
+    {
+      "name": "wrong",
+      "input": { "@type": "type.googleapis.com/clitest.Order" },
+      "result": 1
+    }
+  ]
+}`
+
+// TestTestCommand tests the test command.
+func TestTestCommand(t *testing.T) {
+	path := writeTestFile(t, "order.suite.json", testSuiteJSON)
+	code, stdout, stderr := runTest("", "test", "-v", path)
+	if code != 1 {
+		t.Errorf("expected exit code 1, got %d (stderr: %s)", code, stderr)
+	}
+	if !strings.Contains(stdout, "PASS "+path+"/two_items") {
+		t.Errorf("passing case not reported: %s", stdout)
+	}
+	if !strings.Contains(stdout, "FAIL "+path+"/wrong") ||
+		!strings.Contains(stdout, "result mismatch") {
+		t.Errorf("failing case not reported: %s", stdout)
+	}
+	code, stdout, stderr = runTest("", "test", "-update", path)
+	if code != 0 {
+		t.Fatalf("update: exit code %d (stderr: %s)", code, stderr)
+	}
+	if !strings.Contains(stdout, "updated "+path) {
+		t.Errorf("update not reported: %s", stdout)
+	}
+	code, stdout, stderr = runTest("", "test", path)
+	if code != 0 || strings.TrimSpace(stdout) != "PASS" {
+		t.Errorf("expected pass after update, got exit code %d: %s%s",
+			code, stdout, stderr)
+	}
+	report := filepath.Join(testDir, "coverage.html")
+	code, _, stderr = runTest("", "test", "-coverage", report, path)
+	if code != 0 {
+		t.Fatalf("coverage: exit code %d (stderr: %s)", code, stderr)
+	}
+	data, err := ioutil.ReadFile(report)
+	if err != nil {
+		t.Fatalf("read coverage report: %s", err)
+	}
+	if !strings.Contains(string(data), "Coverage: 1/1 Value nodes (100.0%)") {
+		t.Errorf("unexpected coverage report: %s", data)
+	}
+}
+
+// TestTestUsage tests the usage errors of the test command.
+func TestTestUsage(t *testing.T) {
+	if code, _, _ := runTest("", "test"); code != 2 {
+		t.Errorf("expected exit code 2 without suites, got %d", code)
+	}
+	if code, _, _ := runTest("", "test", "-nope", "x"); code != 2 {
+		t.Errorf("expected exit code 2 for unknown flag, got %d", code)
+	}
+	if code, _, _ := runTest("", "test", "-coverage", "c.txt", "a.json",
+		"b.json"); code != 2 {
+		t.Errorf("expected exit code 2 for coverage of several suites, got %d",
+			code)
+	}
+	if code, _, _ := runTest("", "test", "missing.json"); code != 1 {
+		t.Errorf("expected exit code 1 for missing suite, got %d", code)
+	}
+}
@@ -0,0 +1,450 @@
+package protoeval
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/google/cel-go/common/types"
+	"github.com/google/cel-go/common/types/ref"
+	"github.com/google/cel-go/common/types/traits"
+)
+
+// aggregateElement is an element of an aggregate value.
+type aggregateElement struct {
+	// key is the list index or the map key.
+	key ref.Val
+
+	// value is the element value.
+	value ref.Val
+}
+
+// evalAggregate evaluates the given iterable value, or takes the scope value
+// if iterable is nil, and returns its elements. isMap reports whether the
+// aggregate is a map. If the iterable value is a CEL error value, it is
+// returned as rv.
+func evalAggregate(
+	env *Env, cyclesLeft *int, iterable *Value,
+) (elts []aggregateElement, isMap bool, rv ref.Val, err error) {
+	var agg ref.Val
+	if iterable == nil {
+		agg = env.scope.Value()
+	} else {
+		agg, err = eval(env, cyclesLeft, iterable)
+		if err != nil {
+			return nil, false, agg, fmt.Errorf("eval iterable: %w", err)
+		}
+	}
+	switch x := agg.(type) {
+	case traits.Lister:
+		for i, iter := 0, x.Iterator(); iter.HasNext() == types.True; i++ {
+			elts = append(elts, aggregateElement{
+				key:   types.Int(i),
+				value: iter.Next(),
+			})
+		}
+		return elts, false, nil, nil
+	case traits.Mapper:
+		keys, err := mapKeys(x, env.sortedMapIteration)
+		if err != nil {
+			return nil, true, nil, err
+		}
+		for _, key := range keys {
+			elts = append(elts, aggregateElement{
+				key:   key,
+				value: x.Get(key),
+			})
+		}
+		return elts, true, nil, nil
+	default:
+		if types.IsError(agg) {
+			return nil, false, agg, nil
+		}
+		return nil, false, nil, fmt.Errorf("type %T not iterable", agg.Value())
+	}
+}
+
+// mapKeys returns the keys of the given map. If sorted is true, the keys are
+// sorted as by mapKeyLess.
+func mapKeys(m traits.Mapper, sorted bool) ([]ref.Val, error) {
+	var keys []ref.Val
+	for iter := m.Iterator(); iter.HasNext() == types.True; {
+		keys = append(keys, iter.Next())
+	}
+	if !sorted {
+		return keys, nil
+	}
+	for _, key := range keys {
+		if mapKeyRank(key) < 0 {
+			return nil, fmt.Errorf("cannot sort map key type %T", key)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return mapKeyLess(keys[i], keys[j])
+	})
+	return keys, nil
+}
+
+// mapKeyLess reports whether map key a sorts before map key b: bool keys
+// first (false before true), then int keys, then uint keys (both in numeric
+// order), then string keys (in bytewise order). Both keys must be of a type
+// with non-negative mapKeyRank.
+func mapKeyLess(a, b ref.Val) bool {
+	ra, rb := mapKeyRank(a), mapKeyRank(b)
+	if ra != rb {
+		return ra < rb
+	}
+	switch x := a.(type) {
+	case types.Bool:
+		return !bool(x) && bool(b.(types.Bool))
+	case types.Int:
+		return x < b.(types.Int)
+	case types.Uint:
+		return x < b.(types.Uint)
+	case types.String:
+		return x < b.(types.String)
+	default:
+		panic(fmt.Sprintf("BUG: missing map key case for %T", x))
+	}
+}
+
+// mapKeyRank returns the rank of the type of the given map key for sorting,
+// or -1 if keys of this type cannot be sorted.
+func mapKeyRank(key ref.Val) int {
+	switch key.(type) {
+	case types.Bool:
+		return 0
+	case types.Int:
+		return 1
+	case types.Uint:
+		return 2
+	case types.String:
+		return 3
+	default:
+		return -1
+	}
+}
+
+// evalElement evaluates value for the given element. The element key and
+// value are pushed as the 0-th and 1st argument, respectively, followed by
+// the extra arguments, and dropped again after the evaluation. If value is
+// nil, evalElement yields the element value.
+func evalElement(
+	env *Env, cyclesLeft *int, value *Value, elt aggregateElement,
+	extra ...ref.Val,
+) (ref.Val, error) {
+	if value == nil {
+		return elt.value, nil
+	}
+	for i := len(extra) - 1; i >= 0; i-- {
+		env.scope.PushArg(extra[i])
+	}
+	env.scope.PushArg(elt.value)
+	env.scope.PushArg(elt.key)
+	rv, err := eval(env, cyclesLeft, value)
+	if err2 := env.scope.DropArgs(uint32(2 + len(extra))); err2 != nil {
+		return nil, fmt.Errorf("element %v drop arguments: %w",
+			elt.key.Value(), err2)
+	}
+	if err != nil {
+		return rv, fmt.Errorf("eval element %v: %w", elt.key.Value(), err)
+	}
+	if rv == nil {
+		return types.NullValue, nil
+	}
+	return rv, nil
+}
+
+// evalPredicate is like evalElement, except that the result must be a
+// boolean value. If value is nil, evalPredicate yields true.
+func evalPredicate(
+	env *Env, cyclesLeft *int, value *Value, elt aggregateElement,
+) (ref.Val, error) {
+	if value == nil {
+		return types.True, nil
+	}
+	rv, err := evalElement(env, cyclesLeft, value, elt)
+	if err != nil || types.IsError(rv) {
+		return rv, err
+	}
+	if _, ok := rv.(types.Bool); !ok {
+		return nil, fmt.Errorf("element %v: expected bool, got %T",
+			elt.key.Value(), rv)
+	}
+	return rv, nil
+}
+
+// evalTransform evaluates the transform value kind.
+func evalTransform(
+	env *Env, cyclesLeft *int, it *Value_Iteration,
+) (ref.Val, error) {
+	elts, isMap, rv, err := evalAggregate(env, cyclesLeft, it.Iterable)
+	if err != nil || rv != nil {
+		return rv, err
+	}
+	results := make([]ref.Val, len(elts))
+	for i, elt := range elts {
+		rv, err := evalElement(env, cyclesLeft, it.Value, elt)
+		if err != nil || types.IsError(rv) {
+			return rv, err
+		}
+		results[i] = rv
+	}
+	if !isMap {
+		return types.NewRefValList(celTypeRegistry, results), nil
+	}
+	m := make(map[ref.Val]ref.Val, len(elts))
+	for i, elt := range elts {
+		m[elt.key] = results[i]
+	}
+	return types.NewRefValMap(celTypeRegistry, m), nil
+}
+
+// evalFilter evaluates the filter value kind.
+func evalFilter(
+	env *Env, cyclesLeft *int, it *Value_Iteration,
+) (ref.Val, error) {
+	if it.Value == nil {
+		return nil, errors.New("filter value missing")
+	}
+	elts, isMap, rv, err := evalAggregate(env, cyclesLeft, it.Iterable)
+	if err != nil || rv != nil {
+		return rv, err
+	}
+	var selected []aggregateElement
+	for _, elt := range elts {
+		rv, err := evalPredicate(env, cyclesLeft, it.Value, elt)
+		if err != nil || types.IsError(rv) {
+			return rv, err
+		}
+		if rv == types.True {
+			selected = append(selected, elt)
+		}
+	}
+	if !isMap {
+		results := make([]ref.Val, len(selected))
+		for i, elt := range selected {
+			results[i] = elt.value
+		}
+		return types.NewRefValList(celTypeRegistry, results), nil
+	}
+	m := make(map[ref.Val]ref.Val, len(selected))
+	for _, elt := range selected {
+		m[elt.key] = elt.value
+	}
+	return types.NewRefValMap(celTypeRegistry, m), nil
+}
+
+// evalCount evaluates the count value kind.
+func evalCount(
+	env *Env, cyclesLeft *int, it *Value_Iteration,
+) (ref.Val, error) {
+	elts, _, rv, err := evalAggregate(env, cyclesLeft, it.Iterable)
+	if err != nil || rv != nil {
+		return rv, err
+	}
+	var count types.Int
+	for _, elt := range elts {
+		rv, err := evalPredicate(env, cyclesLeft, it.Value, elt)
+		if err != nil || types.IsError(rv) {
+			return rv, err
+		}
+		if rv == types.True {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// evalSum evaluates the sum value kind.
+func evalSum(
+	env *Env, cyclesLeft *int, it *Value_Iteration,
+) (ref.Val, error) {
+	elts, _, rv, err := evalAggregate(env, cyclesLeft, it.Iterable)
+	if err != nil || rv != nil {
+		return rv, err
+	}
+	var sum ref.Val = types.IntZero
+	for i, elt := range elts {
+		rv, err := evalElement(env, cyclesLeft, it.Value, elt)
+		if err != nil || types.IsError(rv) {
+			return rv, err
+		}
+		if i == 0 {
+			sum = rv
+			continue
+		}
+		sum = celAdd(sum, rv)
+		if types.IsError(sum) {
+			return sum, nil
+		}
+	}
+	return sum, nil
+}
+
+// evalExtremum evaluates the min value kind if less is celLess, or the max
+// value kind if less is celGreater.
+func evalExtremum(
+	env *Env, cyclesLeft *int, it *Value_Iteration, less binaryOperator,
+) (ref.Val, error) {
+	elts, _, rv, err := evalAggregate(env, cyclesLeft, it.Iterable)
+	if err != nil || rv != nil {
+		return rv, err
+	}
+	var result ref.Val = types.NullValue
+	for i, elt := range elts {
+		rv, err := evalElement(env, cyclesLeft, it.Value, elt)
+		if err != nil || types.IsError(rv) {
+			return rv, err
+		}
+		if i == 0 {
+			result = rv
+			continue
+		}
+		cmp := less(rv, result)
+		if types.IsError(cmp) {
+			return cmp, nil
+		}
+		if cmp == types.True {
+			result = rv
+		}
+	}
+	return result, nil
+}
+
+// evalFold evaluates the fold value kind.
+func evalFold(env *Env, cyclesLeft *int, fold *Value_Fold) (ref.Val, error) {
+	if fold.Value == nil {
+		return nil, errors.New("fold value missing")
+	}
+	elts, _, rv, err := evalAggregate(env, cyclesLeft, fold.Iterable)
+	if err != nil || rv != nil {
+		return rv, err
+	}
+	var acc ref.Val = types.NullValue
+	if fold.Init != nil {
+		acc, err = eval(env, cyclesLeft, fold.Init)
+		if err != nil {
+			return acc, fmt.Errorf("eval fold init: %w", err)
+		}
+		if types.IsError(acc) {
+			return acc, nil
+		}
+	}
+	for _, elt := range elts {
+		acc, err = evalElement(env, cyclesLeft, fold.Value, elt, acc)
+		if err != nil || types.IsError(acc) {
+			return acc, err
+		}
+	}
+	return acc, nil
+}
+
+// evalSortBy evaluates the sort_by value kind.
+func evalSortBy(
+	env *Env, cyclesLeft *int, sortBy *Value_SortBy,
+) (ref.Val, error) {
+	elts, isMap, rv, err := evalAggregate(env, cyclesLeft, sortBy.Iterable)
+	if err != nil || rv != nil {
+		return rv, err
+	}
+	if isMap {
+		return nil, errors.New("cannot sort map")
+	}
+	keys := sortBy.Keys
+	if len(keys) == 0 {
+		keys = []*Value_SortBy_Key{{}}
+	}
+	// sortKeys[i][j] is the j-th sort key of the i-th element.
+	sortKeys := make([][]ref.Val, len(elts))
+	for i, elt := range elts {
+		sortKeys[i] = make([]ref.Val, len(keys))
+		for j, key := range keys {
+			rv, err := evalElement(env, cyclesLeft, key.Value, elt)
+			if err != nil {
+				return rv, fmt.Errorf("sort key %d: %w", j, err)
+			}
+			if types.IsError(rv) {
+				return rv, nil
+			}
+			sortKeys[i][j] = rv
+		}
+	}
+	order := make([]int, len(elts))
+	for i := range order {
+		order[i] = i
+	}
+	var cmpErr ref.Val
+	sort.SliceStable(order, func(a, b int) bool {
+		if cmpErr != nil {
+			return false
+		}
+		for j, key := range keys {
+			lhs, rhs := sortKeys[order[a]][j], sortKeys[order[b]][j]
+			if key.Descending {
+				lhs, rhs = rhs, lhs
+			}
+			comparer, ok := lhs.(traits.Comparer)
+			if !ok {
+				cmpErr = types.NoSuchOverloadErr()
+				return false
+			}
+			cmp := comparer.Compare(rhs)
+			if types.IsError(cmp) {
+				cmpErr = cmp
+				return false
+			}
+			if cmp != types.IntZero {
+				return cmp == types.IntNegOne
+			}
+		}
+		return false
+	})
+	if cmpErr != nil {
+		return cmpErr, nil
+	}
+	results := make([]ref.Val, len(elts))
+	for i, j := range order {
+		results[i] = elts[j].value
+	}
+	return types.NewRefValList(celTypeRegistry, results), nil
+}
+
+// evalGroupBy evaluates the group_by value kind.
+func evalGroupBy(
+	env *Env, cyclesLeft *int, groupBy *Value_GroupBy,
+) (ref.Val, error) {
+	if groupBy.Key == nil {
+		return nil, errors.New("group_by key missing")
+	}
+	elts, _, rv, err := evalAggregate(env, cyclesLeft, groupBy.Iterable)
+	if err != nil || rv != nil {
+		return rv, err
+	}
+	groups := make(map[ref.Val][]ref.Val)
+	for _, elt := range elts {
+		key, err := evalElement(env, cyclesLeft, groupBy.Key, elt)
+		if err != nil {
+			return key, fmt.Errorf("group key: %w", err)
+		}
+		switch key.(type) {
+		case types.String, types.Int, types.Uint, types.Bool:
+		default:
+			if types.IsError(key) {
+				return key, nil
+			}
+			return nil, fmt.Errorf("element %v: invalid group key type %T",
+				elt.key.Value(), key)
+		}
+		value, err := evalElement(env, cyclesLeft, groupBy.Value, elt)
+		if err != nil || types.IsError(value) {
+			return value, err
+		}
+		groups[key] = append(groups[key], value)
+	}
+	result := make(map[ref.Val]ref.Val, len(groups))
+	for key, group := range groups {
+		result[key] = types.NewRefValList(celTypeRegistry, group)
+	}
+	return types.NewRefValMap(celTypeRegistry, result), nil
+}
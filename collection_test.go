@@ -0,0 +1,136 @@
+package protoeval
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestCollectionList tests the collection value kinds on a list.
+func TestCollectionList(t *testing.T) {
+	testmsg := &ScopeTest{
+		AList: []int32{3, 1, 2},
+	}
+	env := NewEnv()
+	var list []int64
+	if err := evalIntoJSON(env, testmsg, `
+    { "scope": ["a_list"], "transform": {
+      "value": { "mul": { "values": [ { "arg": 1 }, { "int": 2 } ] } }
+    } }
+  `, &list); err != nil {
+		t.Fatalf("eval transform: %s", err)
+	}
+	if !reflect.DeepEqual(list, []int64{6, 2, 4}) {
+		t.Errorf("expected [6 2 4], got %v", list)
+	}
+	if err := evalIntoJSON(env, testmsg, `
+    { "filter": {
+      "iterable": { "scope": ["a_list"] },
+      "value": { "gt": { "values": [ { "arg": 1 }, { "int": 1 } ] } }
+    } }
+  `, &list); err != nil {
+		t.Fatalf("eval filter: %s", err)
+	}
+	if !reflect.DeepEqual(list, []int64{3, 2}) {
+		t.Errorf("expected [3 2], got %v", list)
+	}
+	for _, tc := range []struct {
+		value    string
+		expected interface{}
+	}{
+		{`{ "scope": ["a_list"], "count": {} }`, int64(3)},
+		{`{ "scope": ["a_list"], "count": {
+      "value": { "lt": { "values": [ { "arg": 1 }, { "int": 3 } ] } }
+    } }`, int64(2)},
+		{`{ "scope": ["a_list"], "sum": {} }`, int64(6)},
+		{`{ "sum": { "iterable": { "basic_value": [] } } }`, int64(0)},
+		{`{ "sum": { "iterable": { "basic_value": [0.5, 1] } } }`, 1.5},
+		{`{ "scope": ["a_list"], "min": {} }`, int64(1)},
+		{`{ "scope": ["a_list"], "max": {} }`, int64(3)},
+		{`{ "max": { "iterable": { "basic_value": ["b", "c", "a"] } } }`, "c"},
+		{`{ "min": { "iterable": { "basic_value": [] } } }`, nil},
+		{`{ "scope": ["a_list"], "fold": {
+      "init": { "int": 0 },
+      "value": { "add": { "values": [
+        { "arg": 2 },
+        { "mul": { "values": [ { "arg": 0 }, { "arg": 1 } ] } }
+      ] } }
+    } }`, int64(5)},
+		{`{ "scope": ["a_list"], "fold": { "value": { "arg": 2 } } }`, nil},
+	} {
+		result, err := evalJSON(env, testmsg, tc.value)
+		if err != nil {
+			t.Errorf("eval %s: %s", tc.value, err)
+			continue
+		}
+		if result != tc.expected {
+			t.Errorf("eval %s: expected %v, got %v", tc.value, tc.expected, result)
+		}
+	}
+}
+
+// TestCollectionMap tests the collection value kinds on a map.
+func TestCollectionMap(t *testing.T) {
+	testmsg := &ScopeTest{
+		AStringMap: map[string]int32{"one": 1, "two": 2, "three": 3},
+	}
+	env := NewEnv()
+	var m map[string]int64
+	if err := evalIntoJSON(env, testmsg, `
+    { "scope": ["a_string_map"], "transform": {
+      "value": { "neg": { "arg": 1 } }
+    } }
+  `, &m); err != nil {
+		t.Fatalf("eval transform: %s", err)
+	}
+	if !reflect.DeepEqual(m, map[string]int64{"one": -1, "two": -2, "three": -3}) {
+		t.Errorf("unexpected transform result %v", m)
+	}
+	if err := evalIntoJSON(env, testmsg, `
+    { "scope": ["a_string_map"], "filter": {
+      "value": { "ne": { "values": [ { "arg": 0 }, { "basic_value": "two" } ] } }
+    } }
+  `, &m); err != nil {
+		t.Fatalf("eval filter: %s", err)
+	}
+	if !reflect.DeepEqual(m, map[string]int64{"one": 1, "three": 3}) {
+		t.Errorf("unexpected filter result %v", m)
+	}
+	for _, tc := range []struct {
+		value    string
+		expected interface{}
+	}{
+		{`{ "scope": ["a_string_map"], "count": {} }`, int64(3)},
+		{`{ "scope": ["a_string_map"], "sum": {} }`, int64(6)},
+		{`{ "scope": ["a_string_map"], "max": { "value": { "arg": 0 } } }`, "two"},
+	} {
+		result, err := evalJSON(env, testmsg, tc.value)
+		if err != nil {
+			t.Errorf("eval %s: %s", tc.value, err)
+			continue
+		}
+		if result != tc.expected {
+			t.Errorf("eval %s: expected %v, got %v", tc.value, tc.expected, result)
+		}
+	}
+}
+
+// TestCollectionErrors tests collection value kind errors.
+func TestCollectionErrors(t *testing.T) {
+	testmsg := &ScopeTest{
+		AScalar: 1,
+		AList:   []int32{1, 2},
+	}
+	env := NewEnv()
+	for _, value := range []string{
+		`{ "scope": ["a_list"], "filter": {} }`,
+		`{ "scope": ["a_list"], "fold": {} }`,
+		`{ "scope": ["a_scalar"], "count": {} }`,
+		`{ "scope": ["a_list"], "count": { "value": { "arg": 1 } } }`,
+		`{ "sum": { "iterable": { "basic_value": [1, "a"] } } }`,
+		`{ "min": { "iterable": { "basic_value": [1, "a"] } } }`,
+	} {
+		if _, err := evalJSON(env, testmsg, value); err == nil {
+			t.Errorf("expected error with %s", value)
+		}
+	}
+}
@@ -2,6 +2,8 @@ package protoeval
 
 //go:generate protoc --go_out=. test.proto
 //go:generate mv -f test.pb.go msg_test.go
+//go:generate protoc --go_out=. test_ext.proto
+//go:generate mv -f test_ext.pb.go msg_ext_test.go
 
 import (
 	"google.golang.org/protobuf/encoding/protojson"
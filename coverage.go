@@ -0,0 +1,211 @@
+package protoeval
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"strings"
+	"sync"
+)
+
+// Coverage collects which Value nodes have been evaluated. A Coverage can be
+// attached to an environment with Env.SetCoverage. Instances of this type are
+// safe for concurrent use.
+//
+// Value nodes are identified by pointer identity. Hence, the Value trees
+// evaluated must not be copied or modified between evaluation and
+// reporting.
+type Coverage struct {
+	// mutex protects hits.
+	mutex sync.Mutex
+
+	// hits maps Value nodes to the number of times they have been evaluated.
+	hits map[*Value]int
+}
+
+// NewCoverage creates a new, empty coverage collector.
+func NewCoverage() *Coverage {
+	return &Coverage{
+		hits: make(map[*Value]int),
+	}
+}
+
+// hit records an evaluation of the given Value node.
+func (c *Coverage) hit(value *Value) {
+	c.mutex.Lock()
+	c.hits[value]++
+	c.mutex.Unlock()
+}
+
+// Hits returns the number of times the given Value node has been evaluated.
+func (c *Coverage) Hits(value *Value) int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.hits[value]
+}
+
+// CoverageNode describes the coverage of a Value node within a Value tree.
+type CoverageNode struct {
+	// Path is the path of the node relative to the root of the Value tree,
+	// using protobuf field names, list indices, and map keys, e. g.,
+	// "switch.cases[1].then". The path of the root node is empty.
+	Path string
+
+	// Kind is the name of the Value kind of the node, e. g., "program", or
+	// the empty string if the node does not specify a kind.
+	Kind string
+
+	// Value is the Value node.
+	Value *Value
+
+	// Hits is the number of times the node has been evaluated.
+	Hits int
+
+	// Depth is the nesting depth of the node, with the root node at depth 0.
+	Depth int
+}
+
+// Nodes returns the coverage of all Value nodes in the Value tree rooted at
+// root, in depth-first pre-order.
+func (c *Coverage) Nodes(root *Value) []CoverageNode {
+	if root == nil {
+		return nil
+	}
+	var nodes []CoverageNode
+	walkValues(root, "", 0, func(value *Value, path string, depth int) {
+		nodes = append(nodes, CoverageNode{
+			Path:  path,
+			Kind:  valueKind(value),
+			Value: value,
+			Hits:  c.Hits(value),
+			Depth: depth,
+		})
+	})
+	return nodes
+}
+
+// valueKind returns the name of the Value kind of value, or the empty string
+// if value does not specify a kind.
+func valueKind(value *Value) string {
+	rmsg := value.ProtoReflect()
+	od := rmsg.Descriptor().Oneofs().ByName("value")
+	fd := rmsg.WhichOneof(od)
+	if fd == nil {
+		return ""
+	}
+	return string(fd.Name())
+}
+
+// coverageSummary returns the number of covered nodes and the total number
+// of nodes.
+func coverageSummary(nodes []CoverageNode) (covered, total int) {
+	for _, node := range nodes {
+		if node.Hits > 0 {
+			covered++
+		}
+	}
+	return covered, len(nodes)
+}
+
+// percentage returns covered/total in percent.
+func percentage(covered, total int) float64 {
+	if total == 0 {
+		return 100
+	}
+	return 100 * float64(covered) / float64(total)
+}
+
+// WriteText writes a text coverage report for the Value tree rooted at root
+// to w. The report lists the Value nodes which have never been evaluated.
+// Descendants of such nodes are not listed separately, as they cannot have
+// been evaluated either.
+func (c *Coverage) WriteText(w io.Writer, root *Value) error {
+	nodes := c.Nodes(root)
+	covered, total := coverageSummary(nodes)
+	if _, err := fmt.Fprintf(w, "coverage: %d/%d Value nodes (%.1f%%)\n",
+		covered, total, percentage(covered, total)); err != nil {
+		return err
+	}
+	uncoveredDepth := -1
+	for _, node := range nodes {
+		if uncoveredDepth >= 0 && node.Depth > uncoveredDepth {
+			continue
+		}
+		uncoveredDepth = -1
+		if node.Hits > 0 {
+			continue
+		}
+		uncoveredDepth = node.Depth
+		path, kind := node.Path, node.Kind
+		if path == "" {
+			path = "<root>"
+		}
+		if kind == "" {
+			kind = "scope value"
+		}
+		if _, err := fmt.Fprintf(w, "not evaluated: %s (%s)\n",
+			path, kind); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// htmlEscaper escapes text within HTML elements. Unlike html.EscapeString,
+// it leaves quotes alone, which keeps JSON readable in the HTML source.
+var htmlEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+
+// coverageHTMLHeader is the beginning of an HTML coverage report. It is
+// formatted with the title and the summary.
+const coverageHTMLHeader = `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>%[1]s</title>
+<style>
+body { font-family: sans-serif; }
+pre { font-family: monospace; line-height: 1.4; }
+.covered { background-color: #e6ffe6; }
+.uncovered { background-color: #ffe0e0; }
+</style>
+</head>
+<body>
+<h1>%[1]s</h1>
+<p>%[2]s</p>
+<pre>`
+
+// coverageHTMLFooter is the end of an HTML coverage report.
+const coverageHTMLFooter = `</pre>
+</body>
+</html>
+`
+
+// WriteHTML writes an HTML coverage report for the Value tree rooted at root
+// to w. The report shows the Value tree in JSON format, with each Value node
+// highlighted according to whether it has been evaluated. The number of
+// evaluations is shown when hovering over a node.
+func (c *Coverage) WriteHTML(w io.Writer, root *Value, title string) error {
+	covered, total := coverageSummary(c.Nodes(root))
+	summary := fmt.Sprintf("Coverage: %d/%d Value nodes (%.1f%%)",
+		covered, total, percentage(covered, total))
+	jw := jsonWriter{
+		escape: htmlEscaper.Replace,
+		decorate: func(value *Value) (string, string) {
+			hits := c.Hits(value)
+			class := "covered"
+			if hits == 0 {
+				class = "uncovered"
+			}
+			return fmt.Sprintf(`<span class="%s" title="evaluated %d times">`,
+				class, hits), "</span>"
+		},
+	}
+	fmt.Fprintf(&jw.sb, coverageHTMLHeader, html.EscapeString(title),
+		html.EscapeString(summary))
+	if root != nil {
+		jw.writeValue(root, "")
+	}
+	jw.sb.WriteString(coverageHTMLFooter)
+	_, err := io.WriteString(w, jw.sb.String())
+	return err
+}
@@ -0,0 +1,124 @@
+package protoeval
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// coverageTestValue is a Value with branches which are not all evaluated
+// for a_scalar == 1.
+const coverageTestValue = `{ "seq": { "values": [
+  { "switch": {
+    "cases": [
+      { "case": { "program": { "code": "scope.value.a_scalar == 1" } },
+        "then": { "int": 1 } },
+      { "case": { "program": { "code": "scope.value.a_scalar == 2" } },
+        "then": { "int": 2 } }
+    ],
+    "default": { "int": 3 }
+  } },
+  { "all_of": { "values": [
+    { "basic_value": false },
+    { "basic_value": true }
+  ] } },
+  { "while": {
+    "case": { "basic_value": false },
+    "then": { "int": 4 }
+  } }
+] } }`
+
+// TestCoverage tests coverage collection and reporting.
+func TestCoverage(t *testing.T) {
+	var value Value
+	if err := protojson.Unmarshal([]byte(coverageTestValue), &value); err != nil {
+		t.Fatalf("unmarshal value: %s", err)
+	}
+	coverage := NewCoverage()
+	env := NewEnv().SetCoverage(coverage)
+	for i := 0; i < 2; i++ {
+		if _, err := Eval(env, &ScopeTest{AScalar: 1}, &value); err != nil {
+			t.Fatalf("eval: %s", err)
+		}
+	}
+	if hits := coverage.Hits(&value); hits != 2 {
+		t.Errorf("expected 2 hits for root, got %d", hits)
+	}
+	uncovered := make(map[string]bool)
+	for _, node := range coverage.Nodes(&value) {
+		if node.Hits == 0 {
+			uncovered[node.Path] = true
+		}
+	}
+	for _, path := range []string{
+		"seq.values[0].switch.cases[1].case",
+		"seq.values[0].switch.cases[1].then",
+		"seq.values[0].switch.default",
+		"seq.values[1].all_of.values[1]",
+		"seq.values[2].while.then",
+	} {
+		if !uncovered[path] {
+			t.Errorf("expected %s to be uncovered", path)
+		}
+		delete(uncovered, path)
+	}
+	for path := range uncovered {
+		t.Errorf("unexpected uncovered node %s", path)
+	}
+	var text bytes.Buffer
+	if err := coverage.WriteText(&text, &value); err != nil {
+		t.Fatalf("write text report: %s", err)
+	}
+	for _, s := range []string{
+		"coverage: 8/13 Value nodes (61.5%)",
+		"not evaluated: seq.values[0].switch.cases[1].then (int)",
+		"not evaluated: seq.values[2].while.then (int)",
+	} {
+		if !strings.Contains(text.String(), s) {
+			t.Errorf("text report lacks %q:\n%s", s, text.String())
+		}
+	}
+	var html bytes.Buffer
+	if err := coverage.WriteHTML(&html, &value, "a <rule>"); err != nil {
+		t.Fatalf("write HTML report: %s", err)
+	}
+	for _, s := range []string{
+		"<title>a &lt;rule&gt;</title>",
+		`"then": <span class="uncovered" title="evaluated 0 times">{`,
+		`"case": <span class="covered" title="evaluated 2 times">{`,
+		`"code": "scope.value.a_scalar == 2"`,
+	} {
+		if !strings.Contains(html.String(), s) {
+			t.Errorf("HTML report lacks %q:\n%s", s, html.String())
+		}
+	}
+}
+
+// TestCoverageNested tests that the text report omits descendants of
+// uncovered nodes.
+func TestCoverageNested(t *testing.T) {
+	var value Value
+	err := protojson.Unmarshal([]byte(`{ "switch": { "cases": [ {
+    "case": { "basic_value": false },
+    "then": { "seq": { "values": [ { "int": 1 }, { "int": 2 } ] } }
+  } ] } }`), &value)
+	if err != nil {
+		t.Fatalf("unmarshal value: %s", err)
+	}
+	coverage := NewCoverage()
+	if _, err = Eval(NewEnv().SetCoverage(coverage), &ScopeTest{},
+		&value); err != nil {
+		t.Fatalf("eval: %s", err)
+	}
+	var text bytes.Buffer
+	if err = coverage.WriteText(&text, &value); err != nil {
+		t.Fatalf("write text report: %s", err)
+	}
+	expected := "coverage: 2/5 Value nodes (40.0%)\n" +
+		"not evaluated: switch.cases[0].then (seq)\n"
+	if text.String() != expected {
+		t.Errorf("expected report:\n%s\ngot:\n%s", expected, text.String())
+	}
+}
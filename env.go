@@ -2,6 +2,7 @@ package protoeval
 
 import (
 	"reflect"
+	"sort"
 
 	"github.com/google/cel-go/common/types"
 	"github.com/google/cel-go/common/types/ref"
@@ -36,6 +37,12 @@ type Env struct {
 	// cyclesLeft is the number of cycles (an evaluation cost measure) left
 	// before we abort an evaluation.
 	cyclesLeft int
+
+	// sortedMapIteration causes maps to be iterated in sorted key order.
+	sortedMapIteration bool
+
+	// coverage collects the evaluated Value nodes, if not nil.
+	coverage *Coverage
 }
 
 // NewEnv creates a new, empty environment.
@@ -81,6 +88,17 @@ func (e *Env) Get(key string) (value interface{}, ok bool) {
 	return value, true
 }
 
+// Keys returns the keys of all values in this environment in ascending
+// order.
+func (e *Env) Keys() []string {
+	keys := make([]string, 0, len(e.values))
+	for key := range e.values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
 // SetEvalMax sets the maximum number of sub-evaluations for an Eval call
 // with this environment. A non-positive value will cause all evaluations to
 // fail. This environment is returned.
@@ -89,13 +107,32 @@ func (e *Env) SetEvalMax(max int) *Env {
 	return e
 }
 
+// SetSortedMapIteration sets whether evaluations with this environment
+// iterate over maps in sorted key order (see the Range.sorted_keys
+// documentation) instead of in unspecified order. This applies to all Value
+// kinds iterating over maps. This environment is returned.
+func (e *Env) SetSortedMapIteration(sorted bool) *Env {
+	e.sortedMapIteration = sorted
+	return e
+}
+
+// SetCoverage sets the coverage collector for evaluations with this
+// environment. If coverage is nil, no coverage is collected. Clones of this
+// environment share the coverage collector. This environment is returned.
+func (e *Env) SetCoverage(coverage *Coverage) *Env {
+	e.coverage = coverage
+	return e
+}
+
 // Clone creates a copy of this environment.
 // Note that values set with Set or through previous evaluations are copied
 // shallowly.
 func (e *Env) Clone() *Env {
 	result := &Env{
-		values:     make(map[string]envValue, len(e.values)),
-		cyclesLeft: e.cyclesLeft,
+		values:             make(map[string]envValue, len(e.values)),
+		cyclesLeft:         e.cyclesLeft,
+		sortedMapIteration: e.sortedMapIteration,
+		coverage:           e.coverage,
 	}
 	for k, v := range e.values {
 		result.values[k] = v
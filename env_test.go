@@ -0,0 +1,40 @@
+package protoeval
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestEnvKeys tests listing environment keys.
+func TestEnvKeys(t *testing.T) {
+	env := NewEnv()
+	for _, key := range []string{"b", "c", "a"} {
+		if err := env.Set(key, 1); err != nil {
+			t.Fatalf("set %s: %s", key, err)
+		}
+	}
+	if _, err := evalJSON(env, &ScopeTest{}, `
+    { "program": { "code": "'x'.store('d')" } }
+  `); err != nil {
+		t.Fatalf("eval store: %s", err)
+	}
+	expected := []string{"a", "b", "c", "d"}
+	if keys := env.Keys(); !reflect.DeepEqual(keys, expected) {
+		t.Errorf("expected %v, got %v", expected, keys)
+	}
+}
+
+// TestEnvReuse tests that arguments do not leak between evaluations with
+// the same environment.
+func TestEnvReuse(t *testing.T) {
+	env := NewEnv()
+	value := &Value{
+		Value: &Value_Arg{Arg: 1},
+	}
+	if _, err := Eval(env, &ScopeTest{}, value, "a", "b"); err != nil {
+		t.Fatalf("eval with two args: %s", err)
+	}
+	if _, err := Eval(env, &ScopeTest{}, value, "c"); err == nil {
+		t.Error("expected error accessing argument from previous evaluation")
+	}
+}
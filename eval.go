@@ -62,6 +62,145 @@ func (errContinue) Is(err error) bool {
 func Eval(
 	env *Env, msg proto.Message, value *Value, args ...interface{},
 ) (interface{}, error) {
+	result, err := evalRoot(env, msg, value, args)
+	if err != nil {
+		return nil, err
+	}
+	if result.Type() == types.NullType {
+		return nil, nil
+	}
+	return result.Value(), nil
+}
+
+// EvalInto is like Eval, except that the result is converted to the type out
+// points to, and stored there. out must be a non-nil pointer. Possible
+// targets include Go scalars, slices, maps, structs (whose fields are
+// matched by name against map keys), and pointers to protobuf messages.
+// If out is itself a protobuf message, the result must be a message of the
+// same type, and out is overwritten with it. If out points to an empty
+// interface, the result is stored as returned by Eval.
+// A null result sets the target to its zero value, or resets out if it is a
+// protobuf message.
+func EvalInto(
+	env *Env, msg proto.Message, value *Value, out interface{},
+	args ...interface{},
+) error {
+	rOut := reflect.ValueOf(out)
+	if rOut.Kind() != reflect.Pointer || rOut.IsNil() {
+		return fmt.Errorf("out must be a non-nil pointer, got %T", out)
+	}
+	result, err := evalRoot(env, msg, value, args)
+	if err != nil {
+		return err
+	}
+	if outMsg, ok := out.(proto.Message); ok {
+		// Generated messages must not be overwritten through reflection, as this
+		// would clobber their internal state.
+		if result.Type() == types.NullType {
+			proto.Reset(outMsg)
+			return nil
+		}
+		resultMsg, ok := result.Value().(proto.Message)
+		if !ok {
+			return fmt.Errorf("cannot convert %s result to message %T",
+				result.Type().TypeName(), out)
+		}
+		if resultMsg.ProtoReflect().Descriptor() !=
+			outMsg.ProtoReflect().Descriptor() {
+			return fmt.Errorf("cannot convert message %s result to message %s",
+				resultMsg.ProtoReflect().Descriptor().FullName(),
+				outMsg.ProtoReflect().Descriptor().FullName())
+		}
+		proto.Reset(outMsg)
+		proto.Merge(outMsg, resultMsg)
+		return nil
+	}
+	target := rOut.Elem()
+	if result.Type() == types.NullType {
+		target.Set(reflect.Zero(target.Type()))
+		return nil
+	}
+	if target.Kind() == reflect.Interface && target.NumMethod() == 0 {
+		target.Set(reflect.ValueOf(result.Value()))
+		return nil
+	}
+	native, err := result.ConvertToNative(target.Type())
+	if err != nil {
+		return fmt.Errorf("convert %s result to %s: %w",
+			result.Type().TypeName(), target.Type(), err)
+	}
+	target.Set(reflect.ValueOf(native))
+	return nil
+}
+
+// EvalToProto is like Eval, except that the result is converted to a protobuf
+// message of the type described by desc. If this type is not linked in the
+// binary, a dynamic message is returned.
+//
+// A message result of the same type is returned as a copy. If desc describes
+// one of the well-known types google.protobuf.Value, google.protobuf.Struct,
+// google.protobuf.ListValue, google.protobuf.Any, google.protobuf.Duration,
+// google.protobuf.Timestamp, or a wrapper type, the result is converted as
+// CEL would. Otherwise, the result must be a map with
+// string keys, which are matched against the field names of the message type
+// as in Value.Message. Map values are converted recursively. Except for
+// google.protobuf.Value, a null result yields an empty message.
+func EvalToProto(
+	env *Env, msg proto.Message, value *Value,
+	desc protoreflect.MessageDescriptor, args ...interface{},
+) (proto.Message, error) {
+	if desc == nil {
+		return nil, errors.New("desc is nil")
+	}
+	result, err := evalRoot(env, msg, value, args)
+	if err != nil {
+		return nil, err
+	}
+	if result.Type() == types.NullType && desc.FullName() != structpbValueName {
+		empty, err := newProtoMessage(desc)
+		if err != nil {
+			return nil, err
+		}
+		return empty.Interface(), nil
+	}
+	return val2proto(result, desc)
+}
+
+// rangeStep handles the outcome of evaluating a range Value for a single
+// element, with result rv and error err, which must be nil or a break or
+// continue error. It reports whether the range ends, and if so, with which
+// result and error. The range ends on the first non-null result, and on
+// break and continue statements targeting an enclosing loop. A break
+// targeting the range ends it with the result of the break statement.
+func rangeStep(rv ref.Val, err error) (result ref.Val, done bool, _ error) {
+	var brk errBreak
+	var cont errContinue
+	switch {
+	case err == nil:
+		return rv, rv != types.NullValue, nil
+	case errors.As(err, &brk):
+		if brk == 1 {
+			if rv == nil {
+				rv = types.NullValue
+			}
+			return rv, true, nil
+		}
+		return rv, true, errBreak(brk - 1)
+	case errors.As(err, &cont):
+		if cont == 1 {
+			return rv, types.IsError(rv), nil
+		}
+		return rv, true, errContinue(cont - 1)
+	default:
+		panic(fmt.Sprintf("BUG: unexpected range element error %s", err))
+	}
+}
+
+// evalRoot sets up the root scope for msg in env with the given arguments,
+// and evaluates value. Evaluation errors are returned as error.
+func evalRoot(
+	env *Env, msg proto.Message, value *Value, args []interface{},
+) (ref.Val, error) {
 	if env == nil {
 		return nil, errors.New("env is nil")
 	}
@@ -85,13 +224,13 @@ func Eval(
 	if err != nil {
 		return nil, err
 	}
+	if result == nil {
+		return types.NullValue, nil
+	}
 	if types.IsError(result) {
 		return nil, fmt.Errorf("evaluation error: %w", result.Value().(error))
 	}
-	if result.Type() == types.NullType {
-		return nil, nil
-	}
-	return result.Value(), nil
+	return result, nil
 }
 
 // eval recursively evaluates msg in the given environment based on value.
@@ -100,6 +239,9 @@ func eval(env *Env, cyclesLeft *int, value *Value) (ref.Val, error) {
 		return nil, ErrEvalTooLong
 	}
 	*cyclesLeft--
+	if env.coverage != nil {
+		env.coverage.hit(value)
+	}
 	// shift scope
 	var err error
 	if value.Scope != nil {
@@ -281,17 +423,39 @@ func eval(env *Env, cyclesLeft *int, value *Value) (ref.Val, error) {
 		if result == nil {
 			return nil, fmt.Errorf("message type '%s' is synthetic", msgName)
 		}
-		fields := result.Descriptor().Fields()
+		for i, path := range x.Message.Clear {
+			if err := checkPath(result.Descriptor(), path.GetValues()); err != nil {
+				return nil, fmt.Errorf("clear path %d in message '%s': %w",
+					i, msgName, err)
+			}
+		}
+		if x.Message.Base != nil {
+			rv, err := eval(env, cyclesLeft, x.Message.Base)
+			if err != nil {
+				return rv, fmt.Errorf("eval message base: %w", err)
+			}
+			if types.IsError(rv) {
+				return rv, nil
+			}
+			if rv.Type() != types.NullType {
+				if err = val2protomsg(rv, result); err != nil {
+					return nil, fmt.Errorf("message base: %w", err)
+				}
+			}
+		}
+		oneofs := make(map[protoreflect.OneofDescriptor]bool)
 		for key, value := range x.Message.Fields {
-			fd := fields.ByName(protoreflect.Name(key))
-			if fd == nil {
-				return nil, fmt.Errorf("field '%s' in message '%s' not found",
-					key, msgName)
+			fd, err := findField(result.Descriptor(), key)
+			if err != nil {
+				return nil, fmt.Errorf("field '%s' in message '%s': %w",
+					key, msgName, err)
 			}
-			oneof := fd.ContainingOneof()
-			if oneof != nil && result.WhichOneof(oneof) != nil {
-				return nil, fmt.Errorf("multiple fields set in oneof '%s'",
-					oneof.FullName())
+			if oneof := fd.ContainingOneof(); oneof != nil {
+				if oneofs[oneof] {
+					return nil, fmt.Errorf("multiple fields set in oneof '%s'",
+						oneof.FullName())
+				}
+				oneofs[oneof] = true
 			}
 			rv, err := eval(env, cyclesLeft, value)
 			if err != nil {
@@ -300,12 +464,19 @@ func eval(env *Env, cyclesLeft *int, value *Value) (ref.Val, error) {
 			if types.IsError(rv) {
 				return rv, nil
 			}
-			fieldValue, err := go2protofd(rv.Value(), result, fd)
+			if err = val2protofield(rv, result, fd); err != nil {
+				return nil, fmt.Errorf("set field '%s': %w", key, err)
+			}
+		}
+		for i, path := range x.Message.Clear {
+			target, err := resolvePath(result, path.GetValues(), false)
 			if err != nil {
-				return nil, fmt.Errorf("convert %T to field '%s' value: %w",
-					rv.Value(), key, err)
+				return nil, fmt.Errorf("clear path %d in message '%s': %w",
+					i, msgName, err)
+			}
+			if target != nil {
+				target.clear()
 			}
-			result.Set(fd, fieldValue)
 		}
 		return celTypeRegistry.NativeToValue(result.Interface()), nil
 	case *Value_BasicMessage:
@@ -424,7 +595,7 @@ func eval(env *Env, cyclesLeft *int, value *Value) (ref.Val, error) {
 		if x.While.Then == nil {
 			return nil, errors.New("while body missing")
 		}
-		var lastValue ref.Val
+		var lastValue ref.Val = types.NullValue
 		for {
 			cond, err := eval(env, cyclesLeft, x.While.Case)
 			if err != nil {
@@ -460,8 +631,8 @@ func eval(env *Env, cyclesLeft *int, value *Value) (ref.Val, error) {
 					}
 					if value != types.NullValue {
 						lastValue = value
-						continue
 					}
+					continue
 				}
 				return value, errContinue(cont - 1)
 			default:
@@ -683,15 +854,7 @@ func eval(env *Env, cyclesLeft *int, value *Value) (ref.Val, error) {
 		if err != nil {
 			return nil, fmt.Errorf("construct CEL program: %w", err)
 		}
-		celScope, err := scope2cel(&env.scope)
-		if err != nil {
-			return nil, fmt.Errorf("construct CEL scope: %w", err)
-		}
-		out, _, err := prg.Eval(map[string]interface{}{
-			"env":   (*celEnv)(env),
-			"scope": celScope,
-			"args":  (*celArgList)(&env.scope),
-		})
+		out, _, err := prg.Eval(newCelActivation(env))
 		if err != nil {
 			return nil, fmt.Errorf("evaluate CEL program: %w", err)
 		}
@@ -720,17 +883,21 @@ func eval(env *Env, cyclesLeft *int, value *Value) (ref.Val, error) {
 					return nil,
 						fmt.Errorf("list range element %d drop index/value: %w", i, err2)
 				}
-				if err != nil {
+				if err != nil && !errors.Is(err, errBreak(0)) &&
+					!errors.Is(err, errContinue(0)) {
 					return rv, fmt.Errorf("eval list range element %d: %w", i, err)
 				}
-				if rv != types.NullValue {
-					return rv, nil
+				if result, done, err := rangeStep(rv, err); done {
+					return result, err
 				}
 			}
 			return types.NullValue, nil
 		case traits.Mapper:
-			for iter := y.Iterator(); iter.HasNext() == types.True; {
-				key := iter.Next()
+			keys, err := mapKeys(y, x.Range.SortedKeys || env.sortedMapIteration)
+			if err != nil {
+				return nil, fmt.Errorf("map range: %w", err)
+			}
+			for _, key := range keys {
 				value := y.Get(key)
 				env.scope.PushArg(value)
 				env.scope.PushArg(key)
@@ -739,11 +906,12 @@ func eval(env *Env, cyclesLeft *int, value *Value) (ref.Val, error) {
 					return nil, fmt.Errorf("map range key %v drop index/value: %w",
 						key.Value(), err2)
 				}
-				if err != nil {
+				if err != nil && !errors.Is(err, errBreak(0)) &&
+					!errors.Is(err, errContinue(0)) {
 					return rv, fmt.Errorf("eval map range key %v: %w", key.Value(), err)
 				}
-				if rv != types.NullValue {
-					return rv, nil
+				if result, done, err := rangeStep(rv, err); done {
+					return result, err
 				}
 			}
 			return types.NullValue, nil
@@ -753,6 +921,97 @@ func eval(env *Env, cyclesLeft *int, value *Value) (ref.Val, error) {
 			}
 			return nil, fmt.Errorf("type %T not iterable", sv.Value())
 		}
+	case *Value_Patch_:
+		return patch(env, cyclesLeft, x.Patch)
+	case *Value_Eq:
+		return evalBinaryOperator(env, cyclesLeft, "eq", x.Eq, celEqual)
+	case *Value_Ne:
+		return evalBinaryOperator(env, cyclesLeft, "ne", x.Ne, celNotEqual)
+	case *Value_Lt:
+		return evalBinaryOperator(env, cyclesLeft, "lt", x.Lt, celLess)
+	case *Value_Le:
+		return evalBinaryOperator(env, cyclesLeft, "le", x.Le, celLessEqual)
+	case *Value_Gt:
+		return evalBinaryOperator(env, cyclesLeft, "gt", x.Gt, celGreater)
+	case *Value_Ge:
+		return evalBinaryOperator(env, cyclesLeft, "ge", x.Ge, celGreaterEqual)
+	case *Value_Add:
+		return evalBinaryOperator(env, cyclesLeft, "add", x.Add, celAdd)
+	case *Value_Sub:
+		return evalBinaryOperator(env, cyclesLeft, "sub", x.Sub, celSubtract)
+	case *Value_Mul:
+		return evalBinaryOperator(env, cyclesLeft, "mul", x.Mul, celMultiply)
+	case *Value_Div:
+		return evalBinaryOperator(env, cyclesLeft, "div", x.Div, celDivide)
+	case *Value_Mod:
+		return evalBinaryOperator(env, cyclesLeft, "mod", x.Mod, celModulo)
+	case *Value_Neg:
+		rv, err := eval(env, cyclesLeft, x.Neg)
+		if err != nil {
+			return rv, fmt.Errorf("eval neg: %w", err)
+		}
+		if types.IsError(rv) {
+			return rv, nil
+		}
+		return celNegate(rv), nil
+	case *Value_Transform:
+		rv, err := evalTransform(env, cyclesLeft, x.Transform)
+		if err != nil {
+			return rv, fmt.Errorf("transform: %w", err)
+		}
+		return rv, nil
+	case *Value_Filter:
+		rv, err := evalFilter(env, cyclesLeft, x.Filter)
+		if err != nil {
+			return rv, fmt.Errorf("filter: %w", err)
+		}
+		return rv, nil
+	case *Value_Count:
+		rv, err := evalCount(env, cyclesLeft, x.Count)
+		if err != nil {
+			return rv, fmt.Errorf("count: %w", err)
+		}
+		return rv, nil
+	case *Value_Sum:
+		rv, err := evalSum(env, cyclesLeft, x.Sum)
+		if err != nil {
+			return rv, fmt.Errorf("sum: %w", err)
+		}
+		return rv, nil
+	case *Value_Min:
+		rv, err := evalExtremum(env, cyclesLeft, x.Min, celLess)
+		if err != nil {
+			return rv, fmt.Errorf("min: %w", err)
+		}
+		return rv, nil
+	case *Value_Max:
+		rv, err := evalExtremum(env, cyclesLeft, x.Max, celGreater)
+		if err != nil {
+			return rv, fmt.Errorf("max: %w", err)
+		}
+		return rv, nil
+	case *Value_Fold_:
+		rv, err := evalFold(env, cyclesLeft, x.Fold)
+		if err != nil {
+			return rv, fmt.Errorf("fold: %w", err)
+		}
+		return rv, nil
+	case *Value_SortBy_:
+		rv, err := evalSortBy(env, cyclesLeft, x.SortBy)
+		if err != nil {
+			return rv, fmt.Errorf("sort_by: %w", err)
+		}
+		return rv, nil
+	case *Value_GroupBy_:
+		rv, err := evalGroupBy(env, cyclesLeft, x.GroupBy)
+		if err != nil {
+			return rv, fmt.Errorf("group_by: %w", err)
+		}
+		return rv, nil
+	case *Value_Try_:
+		return evalTry(env, cyclesLeft, x.Try)
+	case *Value_Fail_:
+		return evalFail(env, cyclesLeft, x.Fail)
 	default:
 		panic(fmt.Sprintf("BUG: unsupported value type %T", value.Value))
 	}
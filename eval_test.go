@@ -0,0 +1,259 @@
+package protoeval
+
+import (
+	"testing"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// evalIntoJSON is like EvalInto, except that the Value is specified as JSON.
+func evalIntoJSON(
+	env *Env, msg proto.Message, jsonValue string, out interface{},
+) error {
+	var value Value
+	err := protojson.UnmarshalOptions{}.Unmarshal([]byte(jsonValue), &value)
+	if err != nil {
+		return err
+	}
+	return EvalInto(env, msg, &value, out)
+}
+
+// TestEvalIntoScalar tests converting a result into a Go scalar.
+func TestEvalIntoScalar(t *testing.T) {
+	testmsg := &ScopeTest{
+		AScalar: 42,
+	}
+	env := NewEnv()
+	var scalar int32
+	if err := evalIntoJSON(env, testmsg, `{ "scope": ["a_scalar"] }`,
+		&scalar); err != nil {
+		t.Fatalf("eval: %s", err)
+	}
+	if scalar != 42 {
+		t.Errorf("expected 42, got %d", scalar)
+	}
+	var str string
+	if err := evalIntoJSON(env, testmsg, `{ "scope": ["a_scalar"] }`,
+		&str); err == nil {
+		t.Error("expected error converting int to string")
+	}
+	if err := evalIntoJSON(env, testmsg, `{}`, scalar); err == nil {
+		t.Error("expected error with non-pointer out")
+	}
+}
+
+// TestEvalIntoAggregate tests converting results into Go slices, maps and
+// structs.
+func TestEvalIntoAggregate(t *testing.T) {
+	testmsg := &ScopeTest{
+		AList:      []int32{1, 2, 3},
+		AnInt64Map: map[int64]int32{-1: 1},
+	}
+	env := NewEnv()
+	var list []int
+	if err := evalIntoJSON(env, testmsg, `{ "scope": ["a_list"] }`,
+		&list); err != nil {
+		t.Fatalf("eval list: %s", err)
+	}
+	if len(list) != 3 || list[0] != 1 || list[1] != 2 || list[2] != 3 {
+		t.Errorf("expected [1 2 3], got %v", list)
+	}
+	var m map[int64]int32
+	if err := evalIntoJSON(env, testmsg, `{ "scope": ["an_int64_map"] }`,
+		&m); err != nil {
+		t.Fatalf("eval map: %s", err)
+	}
+	if len(m) != 1 || m[-1] != 1 {
+		t.Errorf("expected map[-1:1], got %v", m)
+	}
+	var s struct {
+		Name  string
+		Count float64
+	}
+	if err := evalIntoJSON(env, testmsg, `
+    { "basic_value": { "Name": "x", "Count": 2 } }
+  `, &s); err != nil {
+		t.Fatalf("eval struct: %s", err)
+	}
+	if s.Name != "x" || s.Count != 2 {
+		t.Errorf("expected {x 2}, got %v", s)
+	}
+}
+
+// TestEvalIntoMessage tests converting a result into a protobuf message.
+func TestEvalIntoMessage(t *testing.T) {
+	testmsg := &ScopeTest{
+		AMessageList: []*ScopeTest{
+			{AScalar: 42},
+		},
+	}
+	env := NewEnv()
+	var out ScopeTest
+	if err := evalIntoJSON(env, testmsg, `{ "scope": ["a_message_list", 0] }`,
+		&out); err != nil {
+		t.Fatalf("eval: %s", err)
+	}
+	if !proto.Equal(&out, testmsg.AMessageList[0]) {
+		t.Errorf("expected %v, got %v", testmsg.AMessageList[0], &out)
+	}
+	var outPtr *ScopeTest
+	if err := evalIntoJSON(env, testmsg, `{ "scope": ["a_message_list", 0] }`,
+		&outPtr); err != nil {
+		t.Fatalf("eval: %s", err)
+	}
+	if !proto.Equal(outPtr, testmsg.AMessageList[0]) {
+		t.Errorf("expected %v, got %v", testmsg.AMessageList[0], outPtr)
+	}
+	if err := evalIntoJSON(env, testmsg, `{ "scope": ["a_message_list"] }`,
+		&out); err == nil {
+		t.Error("expected error converting list to message")
+	}
+	if err := evalIntoJSON(env, testmsg, `{ "scope": ["a_message_list", 0] }`,
+		&out); err != nil {
+		t.Fatalf("eval: %s", err)
+	}
+	if err := evalIntoJSON(env, testmsg, `{ "basic_value": null }`,
+		&out); err != nil {
+		t.Fatalf("eval null: %s", err)
+	}
+	if !proto.Equal(&out, &ScopeTest{}) || proto.Size(&out) != 0 {
+		t.Errorf("expected empty message after null result, got %v", &out)
+	}
+	if err := evalIntoJSON(env, testmsg, `{ "basic_value": null }`,
+		&outPtr); err != nil {
+		t.Fatalf("eval null: %s", err)
+	}
+	if outPtr != nil {
+		t.Errorf("expected nil message pointer after null result, got %v", outPtr)
+	}
+}
+
+// TestEvalToProto tests converting results to protobuf messages.
+func TestEvalToProto(t *testing.T) {
+	testmsg := &ScopeTest{
+		AScalar:  42,
+		ABoolMap: map[bool]int32{true: 1},
+	}
+	env := NewEnv()
+	var value Value
+	if err := protojson.Unmarshal([]byte(`
+    {
+      "map": { "entries": [
+        {
+          "key": { "basic_value": "a_scalar" },
+          "value": { "scope": ["a_scalar"] }
+        },
+        {
+          "key": { "basic_value": "a_list" },
+          "value": { "basic_value": [1, 2] }
+        },
+        {
+          "key": { "basic_value": "a_message_map" },
+          "value": { "basic_value": { "x": { "a_scalar": 7 } } }
+        },
+        {
+          "key": { "basic_value": "a_bool_map" },
+          "value": { "scope": ["a_bool_map"] }
+        }
+      ]}
+    }
+  `), &value); err != nil {
+		t.Fatalf("unmarshal value: %s", err)
+	}
+	result, err := EvalToProto(env, testmsg, &value,
+		testmsg.ProtoReflect().Descriptor())
+	if err != nil {
+		t.Fatalf("eval to ScopeTest: %s", err)
+	}
+	expected := &ScopeTest{
+		AScalar:  42,
+		AList:    []int32{1, 2},
+		ABoolMap: map[bool]int32{true: 1},
+		AMessageMap: map[string]*ScopeTest{
+			"x": {AScalar: 7},
+		},
+	}
+	if !proto.Equal(expected, result) {
+		t.Errorf("expected %v, got %v", expected, result)
+	}
+	if _, err = EvalToProto(env, testmsg, &value,
+		(&structpb.Struct{}).ProtoReflect().Descriptor()); err == nil {
+		t.Error("expected error converting map with bool keys to Struct")
+	}
+	result, err = EvalToProto(env, testmsg, &Value{
+		Scope: value.Scope,
+		Value: &Value_Map_{
+			Map: &Value_Map{
+				Entries: value.GetMap().Entries[:3],
+			},
+		},
+	}, (&structpb.Struct{}).ProtoReflect().Descriptor())
+	if err != nil {
+		t.Fatalf("eval to Struct: %s", err)
+	}
+	if result.(*structpb.Struct).Fields["a_scalar"].GetNumberValue() != 42 {
+		t.Errorf("unexpected Struct %v", result)
+	}
+	result, err = EvalToProto(env, testmsg, &Value{},
+		(&anypb.Any{}).ProtoReflect().Descriptor())
+	if err != nil {
+		t.Fatalf("eval to Any: %s", err)
+	}
+	unwrapped, err := result.(*anypb.Any).UnmarshalNew()
+	if err != nil {
+		t.Fatalf("unwrap Any: %s", err)
+	}
+	if !proto.Equal(testmsg, unwrapped) {
+		t.Errorf("expected %v, got %v", testmsg, unwrapped)
+	}
+}
+
+// TestEvalWhileContinue tests continue statements without value within a
+// while loop.
+func TestEvalWhileContinue(t *testing.T) {
+	env := NewEnv()
+	if err := env.Set("n", int64(0)); err != nil {
+		t.Fatalf("set n=0 in environment: %s", err)
+	}
+	if err := env.Set("sum", int64(0)); err != nil {
+		t.Fatalf("set sum=0 in environment: %s", err)
+	}
+	if _, err := evalJSON(env, &ScopeTest{}, `
+    { "while": {
+      "case": { "program": { "code": "env.n < 4" } },
+      "then": { "seq": { "values": [
+        { "program": { "code": "(env.n+1).store('n').nix()" } },
+        { "switch": { "cases": [ {
+          "case": { "program": { "code": "env.n % 2 == 0" } },
+          "then": { "continue": 1 }
+        } ] } },
+        { "program": { "code": "(env.sum+env.n).store('sum').nix()" } }
+      ] } }
+    } }
+  `); err != nil {
+		t.Fatalf("eval: %s", err)
+	}
+	if sum, _ := env.Get("sum"); sum != int64(4) {
+		t.Errorf("expected sum=4, got %v", sum)
+	}
+}
+
+// TestEvalWhileNoIteration tests that a while loop whose body never runs
+// yields null, also when nested in another Value.
+func TestEvalWhileNoIteration(t *testing.T) {
+	result, err := evalJSON(NewEnv(), &ScopeTest{}, `
+    { "eq": { "values": [
+      { "while": { "case": { "basic_value": false }, "then": { "int": 4 } } },
+      { "program": { "code": "null" } }
+    ] } }
+  `)
+	if err != nil {
+		t.Fatalf("eval: %s", err)
+	}
+	if result != true {
+		t.Errorf("expected true, got %v", result)
+	}
+}
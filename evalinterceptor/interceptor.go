@@ -0,0 +1,320 @@
+// Package evalinterceptor validates incoming gRPC requests with protoeval
+// Values.
+//
+// A Validator holds rules, i. e., Values looked up per method or per request
+// message type. Its interceptors evaluate the rule for each incoming request
+// message, and reject the call if the rule yields false or a list of
+// violations.
+package evalinterceptor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/TheCount/protoeval"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	spb "google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// ErrorDomain is the domain of the ErrorInfo details of rejections.
+const ErrorDomain = "protoeval"
+
+// Reasons in the ErrorInfo details of rejections.
+const (
+	// ReasonRuleViolated is the reason for rejections due to a rule yielding
+	// false or a list of violations.
+	ReasonRuleViolated = "RULE_VIOLATED"
+
+	// ReasonRuleFailed is the default reason for rejections due to a fail
+	// Value without code. Fail Values with a code use their code as reason.
+	ReasonRuleFailed = "RULE_FAILED"
+)
+
+// Rule describes how to validate a request message.
+type Rule struct {
+	// Value is evaluated against the request message, with the full method
+	// name (e. g., "/pkg.Service/Method") as argument. The request is accepted
+	// if the result is true, null, or an empty list. It is rejected if the
+	// result is false, or a non-empty list of violations. Each violation is
+	// either a string describing the violation, or a map with the string
+	// entries "description" and, optionally, "field", the path of the
+	// offending field. If the evaluation fails due to a fail Value, the
+	// request is rejected with the fail message as violation. Any other
+	// result or evaluation error rejects the request with status INTERNAL.
+	Value *protoeval.Value
+
+	// Code is the status code of rejections. If Code is codes.OK, rejections
+	// use codes.InvalidArgument.
+	Code codes.Code
+}
+
+// Validator validates request messages with rules. Rules are looked up by
+// the full method name first, then by the full name of the request message
+// type. Requests without rule are accepted.
+//
+// Rules must not be changed while the validator is in use. Otherwise,
+// instances of this type are safe for concurrent use.
+type Validator struct {
+	// env is the environment evaluations start from. Each evaluation uses a
+	// clone.
+	env *protoeval.Env
+
+	// methodRules maps full method names to rules.
+	methodRules map[string]Rule
+
+	// typeRules maps message type names to rules.
+	typeRules map[protoreflect.FullName]Rule
+}
+
+// NewValidator creates a new validator without rules. Evaluations take place
+// in clones of env, so env must not be modified while the validator is in
+// use. If env is nil, a new, empty environment is used.
+func NewValidator(env *protoeval.Env) *Validator {
+	if env == nil {
+		env = protoeval.NewEnv()
+	}
+	return &Validator{
+		env:         env,
+		methodRules: make(map[string]Rule),
+		typeRules:   make(map[protoreflect.FullName]Rule),
+	}
+}
+
+// SetMethodRule sets the rule for requests of the given method. fullMethod
+// is the full method name as in grpc.UnaryServerInfo. If the Value of rule is
+// nil, the rule is removed instead. This validator is returned.
+func (v *Validator) SetMethodRule(fullMethod string, rule Rule) *Validator {
+	if rule.Value == nil {
+		delete(v.methodRules, fullMethod)
+	} else {
+		v.methodRules[fullMethod] = rule
+	}
+	return v
+}
+
+// SetTypeRule sets the rule for request messages of the given type. If the
+// Value of rule is nil, the rule is removed instead. This validator is
+// returned.
+func (v *Validator) SetTypeRule(
+	typeName protoreflect.FullName, rule Rule,
+) *Validator {
+	if rule.Value == nil {
+		delete(v.typeRules, typeName)
+	} else {
+		v.typeRules[typeName] = rule
+	}
+	return v
+}
+
+// lookupRule looks up the rule for the given method and request message.
+func (v *Validator) lookupRule(
+	fullMethod string, req proto.Message,
+) (rule Rule, ok bool) {
+	if rule, ok = v.methodRules[fullMethod]; ok {
+		return rule, true
+	}
+	rule, ok = v.typeRules[req.ProtoReflect().Descriptor().FullName()]
+	return rule, ok
+}
+
+// Validate validates the given request message of the given method. It
+// returns nil if the request is accepted, and a status error otherwise.
+// Rejections due to the rule come with ErrorInfo details, and, if there are
+// violations, with BadRequest details listing them.
+func (v *Validator) Validate(fullMethod string, req proto.Message) error {
+	rule, ok := v.lookupRule(fullMethod, req)
+	if !ok {
+		return nil
+	}
+	code := rule.Code
+	if code == codes.OK {
+		code = codes.InvalidArgument
+	}
+	result, err := protoeval.EvalToProto(v.env.Clone(), req, rule.Value,
+		(&structpb.Value{}).ProtoReflect().Descriptor(), fullMethod)
+	var failErr *protoeval.FailError
+	switch {
+	case errors.As(err, &failErr):
+		reason, description := failErr.Code, failErr.Message
+		if reason == "" {
+			reason = ReasonRuleFailed
+		}
+		if description == "" {
+			description = failErr.Error()
+		}
+		return reject(code, fullMethod, reason,
+			[]*errdetails.BadRequest_FieldViolation{{Description: description}})
+	case err != nil:
+		return status.Errorf(codes.Internal, "evaluate rule for %s: %s",
+			fullMethod, err)
+	}
+	switch x := result.(*structpb.Value).Kind.(type) {
+	case *structpb.Value_NullValue:
+		return nil
+	case *structpb.Value_BoolValue:
+		if x.BoolValue {
+			return nil
+		}
+		return reject(code, fullMethod, ReasonRuleViolated, nil)
+	case *structpb.Value_ListValue:
+		if len(x.ListValue.Values) == 0 {
+			return nil
+		}
+		violations, err := convertViolations(x.ListValue.Values)
+		if err != nil {
+			return status.Errorf(codes.Internal, "evaluate rule for %s: %s",
+				fullMethod, err)
+		}
+		return reject(code, fullMethod, ReasonRuleViolated, violations)
+	default:
+		return status.Errorf(codes.Internal,
+			"rule for %s yielded neither bool nor list", fullMethod)
+	}
+}
+
+// convertViolations converts the violations in a rule result to field
+// violations.
+func convertViolations(
+	values []*structpb.Value,
+) ([]*errdetails.BadRequest_FieldViolation, error) {
+	result := make([]*errdetails.BadRequest_FieldViolation, len(values))
+	for i, value := range values {
+		switch x := value.Kind.(type) {
+		case *structpb.Value_StringValue:
+			result[i] = &errdetails.BadRequest_FieldViolation{
+				Description: x.StringValue,
+			}
+		case *structpb.Value_StructValue:
+			fields := x.StructValue.Fields
+			description, ok :=
+				fields["description"].GetKind().(*structpb.Value_StringValue)
+			if !ok {
+				return nil, fmt.Errorf("violation %d: description not a string", i)
+			}
+			field := fields["field"]
+			if field != nil {
+				if _, ok = field.Kind.(*structpb.Value_StringValue); !ok {
+					return nil, fmt.Errorf("violation %d: field not a string", i)
+				}
+			}
+			result[i] = &errdetails.BadRequest_FieldViolation{
+				Field:       field.GetStringValue(),
+				Description: description.StringValue,
+			}
+		default:
+			return nil, fmt.Errorf("violation %d neither string nor map", i)
+		}
+	}
+	return result, nil
+}
+
+// reject returns a status error with the given code for a request of the
+// given method rejected for the given reason.
+func reject(
+	code codes.Code, fullMethod, reason string,
+	violations []*errdetails.BadRequest_FieldViolation,
+) error {
+	msg := fmt.Sprintf("request for %s rejected", fullMethod)
+	switch len(violations) {
+	case 0:
+	case 1:
+		msg += ": " + violations[0].Description
+	default:
+		msg += fmt.Sprintf(": %s (and %d more violations)",
+			violations[0].Description, len(violations)-1)
+	}
+	details := []proto.Message{
+		&errdetails.ErrorInfo{
+			Reason: reason,
+			Domain: ErrorDomain,
+			Metadata: map[string]string{
+				"method": fullMethod,
+			},
+		},
+	}
+	if len(violations) != 0 {
+		details = append(details, &errdetails.BadRequest{
+			FieldViolations: violations,
+		})
+	}
+	st := &spb.Status{
+		Code:    int32(code),
+		Message: msg,
+	}
+	for _, detail := range details {
+		packed, err := anypb.New(detail)
+		if err != nil {
+			// This should not happen since the details are linked in the binary.
+			return status.Error(code, msg)
+		}
+		st.Details = append(st.Details, packed)
+	}
+	return status.ErrorProto(st)
+}
+
+// UnaryServerInterceptor returns a unary server interceptor which validates
+// request messages with this validator.
+func (v *Validator) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context, req interface{}, info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		msg, ok := req.(proto.Message)
+		if !ok {
+			return nil, status.Errorf(codes.Internal,
+				"request for %s is not a protobuf message", info.FullMethod)
+		}
+		if err := v.Validate(info.FullMethod, msg); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor returns a stream server interceptor which
+// validates each request message received on a stream with this validator.
+// A rejected message ends the stream with the rejection.
+func (v *Validator) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(
+		srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		return handler(srv, &validatingStream{
+			ServerStream: ss,
+			validator:    v,
+			fullMethod:   info.FullMethod,
+		})
+	}
+}
+
+// validatingStream is a server stream which validates received messages.
+type validatingStream struct {
+	grpc.ServerStream
+
+	// validator validates the messages.
+	validator *Validator
+
+	// fullMethod is the full method name of the stream.
+	fullMethod string
+}
+
+// RecvMsg implements grpc.ServerStream.RecvMsg.
+func (s *validatingStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	msg, ok := m.(proto.Message)
+	if !ok {
+		return status.Errorf(codes.Internal,
+			"request for %s is not a protobuf message", s.fullMethod)
+	}
+	return s.validator.Validate(s.fullMethod, msg)
+}
@@ -0,0 +1,210 @@
+package evalinterceptor
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/TheCount/protoeval"
+	"github.com/TheCount/protoeval/evalservice"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// newConn starts an in-process gRPC server with an EvalService and a health
+// service, validated by the given validator, and returns a client connection
+// to it.
+func newConn(t *testing.T, v *Validator) *grpc.ClientConn {
+	t.Helper()
+	lis := bufconn.Listen(1 << 20)
+	srv := grpc.NewServer(
+		grpc.UnaryInterceptor(v.UnaryServerInterceptor()),
+		grpc.StreamInterceptor(v.StreamServerInterceptor()))
+	evalservice.RegisterEvalServiceServer(srv, evalservice.NewServer(nil))
+	healthpb.RegisterHealthServer(srv, health.NewServer())
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+	conn, err := grpc.DialContext(context.Background(), "bufconn",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+			return lis.Dial()
+		}),
+		grpc.WithInsecure())
+	if err != nil {
+		t.Fatalf("dial: %s", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+// newProgram returns a Value with the given CEL program.
+func newProgram(code string) *protoeval.Value {
+	return &protoeval.Value{
+		Value: &protoeval.Value_Program_{
+			Program: &protoeval.Value_Program{Code: code},
+		},
+	}
+}
+
+// details returns the ErrorInfo and BadRequest details of the given status
+// error.
+func details(err error) (*errdetails.ErrorInfo, *errdetails.BadRequest) {
+	var info *errdetails.ErrorInfo
+	var badRequest *errdetails.BadRequest
+	for _, detail := range status.Convert(err).Details() {
+		switch x := detail.(type) {
+		case *errdetails.ErrorInfo:
+			info = x
+		case *errdetails.BadRequest:
+			badRequest = x
+		}
+	}
+	return info, badRequest
+}
+
+// evalMethod is the full method name of EvalService.Eval.
+const evalMethod = "/com.github.thecount.protoeval.service.EvalService/Eval"
+
+// TestUnary tests the unary server interceptor.
+func TestUnary(t *testing.T) {
+	v := NewValidator(nil).
+		SetMethodRule(evalMethod, Rule{
+			Value: newProgram("scope.value.rule != 'secret' && args[0] == '" +
+				evalMethod + "'"),
+			Code: codes.PermissionDenied,
+		}).
+		SetTypeRule("com.github.thecount.protoeval.service.ValidateRequest",
+			Rule{
+				Value: newProgram(`has(scope.value.value) ? [] : [
+					{'field': 'value', 'description': 'value missing'}, 'bad'
+				]`),
+			}).
+		SetTypeRule("com.github.thecount.protoeval.service.EvalRequest", Rule{
+			Value: newProgram("false"),
+		}).
+		SetTypeRule("com.github.thecount.protoeval.service.BatchEvalRequest",
+			Rule{
+				Value: &protoeval.Value{
+					Value: &protoeval.Value_Fail_{
+						Fail: &protoeval.Value_Fail{
+							Code:    "BATCH_DISABLED",
+							Message: newProgram("'batch disabled'"),
+						},
+					},
+				},
+			})
+	client := evalservice.NewEvalServiceClient(newConn(t, v))
+	ctx := context.Background()
+	msg, err := anypb.New(&evalservice.Finding{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The method rule takes precedence over the type rule.
+	resp, err := client.Eval(ctx, &evalservice.EvalRequest{
+		Value:   newProgram("1.0"),
+		Message: msg,
+	})
+	if err != nil {
+		t.Fatalf("accepted request: %s", err)
+	}
+	if resp.GetResult().GetNumberValue() != 1 {
+		t.Errorf("unexpected response %s", resp)
+	}
+	_, err = client.Eval(ctx, &evalservice.EvalRequest{
+		Rule:    "secret",
+		Message: msg,
+	})
+	if status.Code(err) != codes.PermissionDenied {
+		t.Errorf("expected permission denied error, got %v", err)
+	}
+	info, badRequest := details(err)
+	if info.GetReason() != ReasonRuleViolated ||
+		info.GetDomain() != ErrorDomain ||
+		info.GetMetadata()["method"] != evalMethod || badRequest != nil {
+		t.Errorf("unexpected details %s, %s", info, badRequest)
+	}
+
+	// Violation list
+	if _, err = client.Validate(ctx, &evalservice.ValidateRequest{
+		Value: newProgram("true"),
+	}); err != nil {
+		t.Errorf("accepted request: %s", err)
+	}
+	_, err = client.Validate(ctx, &evalservice.ValidateRequest{})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected invalid argument error, got %v", err)
+	}
+	info, badRequest = details(err)
+	violations := badRequest.GetFieldViolations()
+	if info.GetReason() != ReasonRuleViolated || len(violations) != 2 ||
+		violations[0].Field != "value" ||
+		violations[0].Description != "value missing" ||
+		violations[1].Field != "" || violations[1].Description != "bad" {
+		t.Errorf("unexpected details %s, %s", info, badRequest)
+	}
+
+	// Fail Value
+	_, err = client.BatchEval(ctx, &evalservice.BatchEvalRequest{})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected invalid argument error, got %v", err)
+	}
+	info, badRequest = details(err)
+	violations = badRequest.GetFieldViolations()
+	if info.GetReason() != "BATCH_DISABLED" || len(violations) != 1 ||
+		violations[0].Description != "batch disabled" {
+		t.Errorf("unexpected details %s, %s", info, badRequest)
+	}
+}
+
+// TestBadRule tests that bad rules reject requests with an internal error.
+func TestBadRule(t *testing.T) {
+	for _, code := range []string{"1.0", "nope", "[1.0]", "[{'field': 'x'}]"} {
+		v := NewValidator(nil).SetMethodRule(evalMethod, Rule{
+			Value: newProgram(code),
+		})
+		client := evalservice.NewEvalServiceClient(newConn(t, v))
+		_, err := client.Eval(context.Background(), &evalservice.EvalRequest{})
+		if status.Code(err) != codes.Internal {
+			t.Errorf("%s: expected internal error, got %v", code, err)
+		}
+	}
+}
+
+// TestStream tests the stream server interceptor.
+func TestStream(t *testing.T) {
+	v := NewValidator(nil).SetTypeRule("grpc.health.v1.HealthCheckRequest",
+		Rule{
+			Value: newProgram("scope.value.service != 'forbidden'"),
+			Code:  codes.PermissionDenied,
+		})
+	client := healthpb.NewHealthClient(newConn(t, v))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stream, err := client.Watch(ctx, &healthpb.HealthCheckRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := stream.Recv()
+	if err != nil {
+		t.Fatalf("accepted request: %s", err)
+	}
+	if resp.Status != healthpb.HealthCheckResponse_SERVING {
+		t.Errorf("unexpected response %s", resp)
+	}
+	stream, err = client.Watch(ctx, &healthpb.HealthCheckRequest{
+		Service: "forbidden",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = stream.Recv()
+	if status.Code(err) != codes.PermissionDenied {
+		t.Errorf("expected permission denied error, got %v", err)
+	}
+}
@@ -0,0 +1,280 @@
+package evalservice
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/TheCount/protoeval"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// MaxHTTPRequestSize is the maximum size of an HTTP request body accepted by
+// the handler returned by NewHTTPHandler.
+const MaxHTTPRequestSize = 4 << 20
+
+// Error codes in HTTP responses.
+const (
+	// httpInvalidRequest is the error code for malformed requests.
+	httpInvalidRequest = "invalid_request"
+
+	// httpNotFound is the error code for unknown rules and message types.
+	httpNotFound = "not_found"
+
+	// httpMethodNotAllowed is the error code for requests with a method other
+	// than POST.
+	httpMethodNotAllowed = "method_not_allowed"
+
+	// httpEvalFailed is the error code for failed evaluations.
+	httpEvalFailed = "eval_failed"
+
+	// httpEvalTooLong is the error code for evaluations aborted because they
+	// took too long.
+	httpEvalTooLong = "eval_too_long"
+
+	// httpFail is the error code for evaluations failed due to a fail Value.
+	httpFail = "fail"
+)
+
+// httpRequest is the body of an HTTP evaluation request.
+type httpRequest struct {
+	// Value is the Value to evaluate, in protojson encoding.
+	Value json.RawMessage `json:"value"`
+
+	// Rule is the name of a preloaded Value, as alternative to Value.
+	Rule string `json:"rule"`
+
+	// Type is the full name of the message type.
+	Type string `json:"type"`
+
+	// Message is the message to be evaluated, in protojson encoding.
+	Message json.RawMessage `json:"message"`
+
+	// Args is the list of evaluation arguments.
+	Args json.RawMessage `json:"args"`
+
+	// Env maps keys to additional environment values.
+	Env json.RawMessage `json:"env"`
+}
+
+// httpResponse is the body of an HTTP evaluation response. Exactly one of
+// its fields is set.
+type httpResponse struct {
+	// Result is the evaluation result, converted to a JSON value.
+	Result json.RawMessage `json:"result,omitempty"`
+
+	// Error describes why the request failed.
+	Error *httpError `json:"error,omitempty"`
+}
+
+// httpError describes a failed HTTP evaluation request.
+type httpError struct {
+	// Code is the error code.
+	Code string `json:"code"`
+
+	// Message is the error message.
+	Message string `json:"message"`
+
+	// FailCode is the code of the fail Value the evaluation failed with, if
+	// any.
+	FailCode string `json:"fail_code,omitempty"`
+}
+
+// httpHandler is the handler returned by NewHTTPHandler.
+type httpHandler struct {
+	// server is the underlying EvalService server.
+	server *Server
+}
+
+// NewHTTPHandler returns an HTTP handler which evaluates messages according
+// to Values, using the environment and the rules of the given server. This
+// allows evaluations without a protobuf toolchain, e. g., with curl.
+//
+// The handler accepts POST requests with a JSON object as body, with the
+// following members:
+//
+//   - value: the Value to evaluate, in protobuf JSON encoding;
+//   - rule: the name of a rule preloaded into the server, as alternative to
+//     value;
+//   - type: the full name of the message type, which must be registered with
+//     the global protobuf type registry (required);
+//   - message: the message to be evaluated, in protobuf JSON encoding
+//     (required);
+//   - args: the list of evaluation arguments (optional);
+//   - env: an object with additional environment values (optional). A null
+//     value removes an environment value of the server.
+//
+// A successful evaluation yields a response with status 200 and a JSON
+// object with the member result, which is the result converted to a JSON
+// value. Otherwise, the response is a JSON object with the member error,
+// which is an object with the members code and message, and, for errors due
+// to a fail Value, fail_code. The error codes and statuses are:
+//
+//   - invalid_request (400): the request is malformed;
+//   - not_found (404): the rule or message type is unknown;
+//   - method_not_allowed (405): the request method is not POST;
+//   - eval_failed (422): the evaluation failed;
+//   - eval_too_long (422): the evaluation took too long;
+//   - fail (422): the evaluation failed due to a fail Value.
+func NewHTTPHandler(server *Server) http.Handler {
+	return httpHandler{
+		server: server,
+	}
+}
+
+// ServeHTTP implements http.Handler.ServeHTTP.
+func (h httpHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		writeHTTPError(w, http.StatusMethodNotAllowed, &httpError{
+			Code:    httpMethodNotAllowed,
+			Message: fmt.Sprintf("method %s not allowed", r.Method),
+		})
+		return
+	}
+	resp, err := h.eval(r.Context(),
+		http.MaxBytesReader(w, r.Body, MaxHTTPRequestSize))
+	if r.Context().Err() != nil {
+		// The client is gone, so nobody receives a response.
+		return
+	}
+	if err != nil {
+		code, httpStatus := httpInvalidRequest, http.StatusBadRequest
+		if status.Code(err) == codes.NotFound {
+			code, httpStatus = httpNotFound, http.StatusNotFound
+		}
+		writeHTTPError(w, httpStatus, &httpError{
+			Code:    code,
+			Message: status.Convert(err).Message(),
+		})
+		return
+	}
+	switch x := resp.Outcome.(type) {
+	case *EvalResponse_Result:
+		result, err := protojson.Marshal(x.Result)
+		if err != nil {
+			writeHTTPError(w, http.StatusInternalServerError, &httpError{
+				Code:    httpEvalFailed,
+				Message: fmt.Sprintf("encode result: %s", err),
+			})
+			return
+		}
+		writeHTTPResponse(w, http.StatusOK, &httpResponse{Result: result})
+	case *EvalResponse_Error:
+		e := &httpError{
+			Code:     httpEvalFailed,
+			Message:  x.Error.Message,
+			FailCode: x.Error.FailCode,
+		}
+		switch {
+		case x.Error.TooLong:
+			e.Code = httpEvalTooLong
+		case x.Error.FailCode != "":
+			e.Code = httpFail
+		}
+		writeHTTPError(w, http.StatusUnprocessableEntity, e)
+	default:
+		panic(fmt.Sprintf("BUG: unsupported outcome type %T", resp.Outcome))
+	}
+}
+
+// eval decodes and evaluates the HTTP request with the given body. Unknown
+// JSON object members are rejected to catch typos. Malformed requests yield
+// an INVALID_ARGUMENT or NOT_FOUND status error. If ctx is done before the
+// evaluation, the request is not evaluated.
+func (h httpHandler) eval(
+	ctx context.Context, body io.Reader,
+) (*EvalResponse, error) {
+	dec := json.NewDecoder(body)
+	dec.DisallowUnknownFields()
+	var req httpRequest
+	if err := dec.Decode(&req); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "decode request: %s",
+			err)
+	}
+	var err error
+	var value *protoeval.Value
+	if isPresent(req.Value) {
+		value = &protoeval.Value{}
+		if err = protojson.Unmarshal(req.Value, value); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "decode value: %s",
+				err)
+		}
+	}
+	if value, err = h.server.lookupValue(value, req.Rule); err != nil {
+		return nil, err
+	}
+	if value == nil {
+		return nil, status.Error(codes.InvalidArgument, "value missing")
+	}
+	if req.Type == "" {
+		return nil, status.Error(codes.InvalidArgument, "type missing")
+	}
+	mt, err := protoregistry.GlobalTypes.FindMessageByName(
+		protoreflect.FullName(req.Type))
+	if errors.Is(err, protoregistry.NotFound) {
+		return nil, status.Errorf(codes.NotFound, "unknown message type %s",
+			req.Type)
+	} else if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "find message type: %s",
+			err)
+	}
+	if !isPresent(req.Message) {
+		return nil, status.Error(codes.InvalidArgument, "message missing")
+	}
+	msg := mt.New().Interface()
+	if err = protojson.Unmarshal(req.Message, msg); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "decode message: %s",
+			err)
+	}
+	var args structpb.ListValue
+	if isPresent(req.Args) {
+		if err = protojson.Unmarshal(req.Args, &args); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "decode args: %s",
+				err)
+		}
+	}
+	var env structpb.Struct
+	if isPresent(req.Env) {
+		if err = protojson.Unmarshal(req.Env, &env); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "decode env: %s", err)
+		}
+	}
+	if err = ctx.Err(); err != nil {
+		return nil, status.FromContextError(err).Err()
+	}
+	return h.server.evalMessage(msg, value, args.Values, env.Fields)
+}
+
+// isPresent reports whether the given JSON object member is present and not
+// null.
+func isPresent(member json.RawMessage) bool {
+	return len(member) != 0 && string(member) != "null"
+}
+
+// writeHTTPError writes an HTTP response with the given status and error.
+func writeHTTPError(w http.ResponseWriter, httpStatus int, e *httpError) {
+	writeHTTPResponse(w, httpStatus, &httpResponse{Error: e})
+}
+
+// writeHTTPResponse writes an HTTP response with the given status and body.
+func writeHTTPResponse(
+	w http.ResponseWriter, httpStatus int, resp *httpResponse,
+) {
+	data, err := json.Marshal(resp)
+	if err != nil {
+		// The response consists of strings and valid JSON only.
+		panic(err)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(httpStatus)
+	w.Write(append(data, '\n'))
+}
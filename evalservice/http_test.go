@@ -0,0 +1,189 @@
+package evalservice
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/TheCount/protoeval"
+)
+
+// TestHTTPHandler tests the HTTP handler.
+func TestHTTPHandler(t *testing.T) {
+	env := protoeval.NewEnv()
+	if err := env.Set("prefix", "ab"); err != nil {
+		t.Fatal(err)
+	}
+	server := NewServer(env).SetRule("has_prefix",
+		newProgram("scope.value.message.startsWith(env['prefix'])"))
+	handler := NewHTTPHandler(server)
+	const finding = `"type": "com.github.thecount.protoeval.service.Finding"`
+	for _, test := range []struct {
+		name   string
+		method string
+		body   string
+		status int
+		resp   string
+	}{
+		{
+			name: "value",
+			body: `{
+				"value": { "program": { "code": "[scope.value.message, args[0]]" } },
+				` + finding + `,
+				"message": { "message": "abc" },
+				"args": [ 1 ]
+			}`,
+			status: http.StatusOK,
+			resp:   `{"result": ["abc", 1]}`,
+		},
+		{
+			name: "rule",
+			body: `{
+				"rule": "has_prefix",
+				` + finding + `,
+				"message": { "message": "xyz" },
+				"env": { "prefix": "xy" }
+			}`,
+			status: http.StatusOK,
+			resp:   `{"result": true}`,
+		},
+		{
+			name: "null result",
+			body: `{
+				"value": { "program": { "code": "null" } },
+				` + finding + `,
+				"message": {}
+			}`,
+			status: http.StatusOK,
+			resp:   `{"result": null}`,
+		},
+		{
+			name: "eval error",
+			body: `{
+				"value": { "program": { "code": "nope" } },
+				` + finding + `,
+				"message": {}
+			}`,
+			status: http.StatusUnprocessableEntity,
+			resp:   `{"error": {"code": "eval_failed"}}`,
+		},
+		{
+			name: "fail",
+			body: `{
+				"value": { "fail": { "code": "E1" } },
+				` + finding + `,
+				"message": {}
+			}`,
+			status: http.StatusUnprocessableEntity,
+			resp:   `{"error": {"code": "fail", "fail_code": "E1"}}`,
+		},
+		{
+			name: "unknown rule",
+			body: `{
+				"rule": "nope",
+				` + finding + `,
+				"message": {}
+			}`,
+			status: http.StatusNotFound,
+			resp:   `{"error": {"code": "not_found"}}`,
+		},
+		{
+			name: "unknown type",
+			body: `{
+				"rule": "has_prefix",
+				"type": "no.Such",
+				"message": {}
+			}`,
+			status: http.StatusNotFound,
+			resp:   `{"error": {"code": "not_found"}}`,
+		},
+		{
+			name: "unknown member",
+			body: `{
+				"rule": "has_prefix",
+				` + finding + `,
+				"mesage": {}
+			}`,
+			status: http.StatusBadRequest,
+			resp:   `{"error": {"code": "invalid_request"}}`,
+		},
+		{
+			name: "message missing",
+			body: `{
+				"rule": "has_prefix",
+				` + finding + `
+			}`,
+			status: http.StatusBadRequest,
+			resp:   `{"error": {"code": "invalid_request"}}`,
+		},
+		{
+			name: "bad message",
+			body: `{
+				"rule": "has_prefix",
+				` + finding + `,
+				"message": { "nope": 1 }
+			}`,
+			status: http.StatusBadRequest,
+			resp:   `{"error": {"code": "invalid_request"}}`,
+		},
+		{
+			name:   "method",
+			method: http.MethodGet,
+			status: http.StatusMethodNotAllowed,
+			resp:   `{"error": {"code": "method_not_allowed"}}`,
+		},
+	} {
+		method := test.method
+		if method == "" {
+			method = http.MethodPost
+		}
+		req := httptest.NewRequest(method, "/", strings.NewReader(test.body))
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		if rec.Code != test.status {
+			t.Errorf("%s: expected status %d, got %d (%s)", test.name,
+				test.status, rec.Code, rec.Body)
+		}
+		if ct := rec.Header().Get("Content-Type"); ct != "application/json" {
+			t.Errorf("%s: unexpected content type %s", test.name, ct)
+		}
+		var resp, expected map[string]interface{}
+		if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+			t.Errorf("%s: decode response: %s", test.name, err)
+			continue
+		}
+		if err := json.Unmarshal([]byte(test.resp), &expected); err != nil {
+			t.Fatalf("%s: decode expected response: %s", test.name, err)
+		}
+		if e, ok := resp["error"].(map[string]interface{}); ok {
+			if e["message"] == "" || e["message"] == nil {
+				t.Errorf("%s: error message missing", test.name)
+			}
+			delete(e, "message")
+		}
+		if !reflect.DeepEqual(resp, expected) {
+			t.Errorf("%s: expected %s, got %s", test.name, test.resp, rec.Body)
+		}
+	}
+}
+
+// TestHTTPHandlerContextDone tests that requests of clients which are gone
+// are not evaluated.
+func TestHTTPHandlerContextDone(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{
+		"value": { "program": { "code": "scope.value.message" } },
+		"type": "com.github.thecount.protoeval.service.Finding",
+		"message": { "message": "abc" }
+	}`)).WithContext(ctx)
+	rec := httptest.NewRecorder()
+	NewHTTPHandler(NewServer(nil)).ServeHTTP(rec, req)
+	if rec.Body.Len() != 0 {
+		t.Errorf("expected no response, got %s", rec.Body)
+	}
+}
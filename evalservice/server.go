@@ -0,0 +1,228 @@
+// Package evalservice provides protoeval as a gRPC service, so programs not
+// written in Go can evaluate Values, e. g., over a local socket. The service
+// is defined in protoeval/eval_service.proto.
+//
+// Messages to be evaluated are passed as Any messages, so their types must be
+// registered with the global protobuf type registry of the server. Values can
+// be passed with each request, or preloaded into the server as named rules.
+//
+// For clients without a protobuf toolchain, NewHTTPHandler provides
+// evaluations over HTTP with JSON request and response bodies.
+package evalservice
+
+//go:generate protoc --proto_path=.. --go_out=.. --go-grpc_out=.. ../protoeval/eval_service.proto
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/TheCount/protoeval"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// Server implements EvalServiceServer. Instances of this type are safe for
+// concurrent use.
+type Server struct {
+	UnimplementedEvalServiceServer
+
+	// env is the environment evaluations start from. Each evaluation uses a
+	// clone.
+	env *protoeval.Env
+
+	// rules maps rule names to preloaded Values.
+	rules map[string]*protoeval.Value
+}
+
+// NewServer creates a new EvalService server. Evaluations take place in
+// clones of env, so env must not be modified while the server is in use. If
+// env is nil, a new, empty environment is used.
+func NewServer(env *protoeval.Env) *Server {
+	if env == nil {
+		env = protoeval.NewEnv()
+	}
+	return &Server{
+		env:   env,
+		rules: make(map[string]*protoeval.Value),
+	}
+}
+
+// SetRule preloads the given Value under the given name, so requests can
+// refer to it by name. If value is nil, the rule is removed instead. Rules
+// must not be changed while the server is in use. This server is returned.
+func (s *Server) SetRule(name string, value *protoeval.Value) *Server {
+	if value == nil {
+		delete(s.rules, name)
+	} else {
+		s.rules[name] = value
+	}
+	return s
+}
+
+// Eval implements EvalServiceServer.Eval. If ctx is already done, the
+// request is not evaluated.
+func (s *Server) Eval(
+	ctx context.Context, req *EvalRequest,
+) (*EvalResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, status.FromContextError(err).Err()
+	}
+	return s.eval(req, nil)
+}
+
+// BatchEval implements EvalServiceServer.BatchEval. The batch is aborted as
+// soon as ctx is done.
+func (s *Server) BatchEval(
+	ctx context.Context, req *BatchEvalRequest,
+) (*BatchEvalResponse, error) {
+	result := &BatchEvalResponse{
+		Responses: make([]*EvalResponse, len(req.Requests)),
+	}
+	for i, r := range req.Requests {
+		if err := ctx.Err(); err != nil {
+			return nil, status.FromContextError(err).Err()
+		}
+		resp, err := s.eval(r, req.Value)
+		if err != nil {
+			st := status.Convert(err)
+			return nil, status.Errorf(st.Code(), "request %d: %s", i, st.Message())
+		}
+		result.Responses[i] = resp
+	}
+	return result, nil
+}
+
+// eval evaluates the given request. If the request has neither a Value nor
+// a rule, defaultValue is used. Malformed requests yield an INVALID_ARGUMENT
+// or NOT_FOUND status error.
+func (s *Server) eval(
+	req *EvalRequest, defaultValue *protoeval.Value,
+) (*EvalResponse, error) {
+	value, err := s.lookupValue(req.Value, req.Rule)
+	if err != nil {
+		return nil, err
+	}
+	if value == nil {
+		value = defaultValue
+	}
+	if value == nil {
+		return nil, status.Error(codes.InvalidArgument, "value missing")
+	}
+	if req.Message == nil {
+		return nil, status.Error(codes.InvalidArgument, "message missing")
+	}
+	msg, err := req.Message.UnmarshalNew()
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "decode message: %s",
+			err)
+	}
+	return s.evalMessage(msg, value, req.Args, req.Env)
+}
+
+// lookupValue returns value, or the preloaded Value for rule if rule is not
+// empty. If neither is set, nil is returned.
+func (s *Server) lookupValue(
+	value *protoeval.Value, rule string,
+) (*protoeval.Value, error) {
+	if rule == "" {
+		return value, nil
+	}
+	if value != nil {
+		return nil, status.Error(codes.InvalidArgument,
+			"both value and rule set")
+	}
+	value, ok := s.rules[rule]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "unknown rule %s", rule)
+	}
+	return value, nil
+}
+
+// evalMessage evaluates msg according to value with the given arguments and
+// environment values. Invalid environment values yield an INVALID_ARGUMENT
+// status error.
+func (s *Server) evalMessage(
+	msg proto.Message, value *protoeval.Value, args []*structpb.Value,
+	envValues map[string]*structpb.Value,
+) (*EvalResponse, error) {
+	env := s.env.Clone()
+	for key, v := range envValues {
+		if err := env.Set(key, v.AsInterface()); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "set env %s: %s",
+				key, err)
+		}
+	}
+	evalArgs := make([]interface{}, len(args))
+	for i, arg := range args {
+		evalArgs[i] = arg.AsInterface()
+	}
+	result, err := protoeval.EvalToProto(env, msg, value,
+		(&structpb.Value{}).ProtoReflect().Descriptor(), evalArgs...)
+	if err != nil {
+		return &EvalResponse{
+			Outcome: &EvalResponse_Error{Error: newEvalError(err)},
+		}, nil
+	}
+	return &EvalResponse{
+		Outcome: &EvalResponse_Result{Result: result.(*structpb.Value)},
+	}, nil
+}
+
+// newEvalError converts the given evaluation error to an EvalError.
+func newEvalError(err error) *EvalError {
+	result := &EvalError{
+		Message: err.Error(),
+		TooLong: errors.Is(err, protoeval.ErrEvalTooLong),
+	}
+	var failErr *protoeval.FailError
+	if errors.As(err, &failErr) {
+		result.FailCode = failErr.Code
+	}
+	return result
+}
+
+// Validate implements EvalServiceServer.Validate. The Value is checked with
+// protoeval.Lint.
+func (s *Server) Validate(
+	ctx context.Context, req *ValidateRequest,
+) (*ValidateResponse, error) {
+	if req.Value == nil {
+		return nil, status.Error(codes.InvalidArgument, "value missing")
+	}
+	result := &ValidateResponse{
+		Valid: true,
+	}
+	for _, finding := range protoeval.Lint(req.Value) {
+		severity, err := convertSeverity(finding.Severity)
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		if severity == Severity_SEVERITY_ERROR {
+			result.Valid = false
+		}
+		result.Findings = append(result.Findings, &Finding{
+			RuleId:   finding.RuleID,
+			Severity: severity,
+			Path:     finding.Path,
+			Message:  finding.Message,
+		})
+	}
+	return result, nil
+}
+
+// convertSeverity converts a lint severity to its protobuf representation.
+func convertSeverity(severity protoeval.Severity) (Severity, error) {
+	switch severity {
+	case protoeval.SeverityInfo:
+		return Severity_SEVERITY_INFO, nil
+	case protoeval.SeverityWarning:
+		return Severity_SEVERITY_WARNING, nil
+	case protoeval.SeverityError:
+		return Severity_SEVERITY_ERROR, nil
+	default:
+		return 0, fmt.Errorf("unsupported severity %s", severity)
+	}
+}
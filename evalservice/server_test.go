@@ -0,0 +1,366 @@
+package evalservice
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/TheCount/protoeval"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// newClient starts an in-process gRPC server for the given EvalService
+// server and returns a client connected to it.
+func newClient(t *testing.T, server *Server) EvalServiceClient {
+	t.Helper()
+	lis := bufconn.Listen(1 << 20)
+	srv := grpc.NewServer()
+	RegisterEvalServiceServer(srv, server)
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+	conn, err := grpc.DialContext(context.Background(), "bufconn",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+			return lis.Dial()
+		}),
+		grpc.WithInsecure())
+	if err != nil {
+		t.Fatalf("dial: %s", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return NewEvalServiceClient(conn)
+}
+
+// newProgram returns a Value with the given CEL program.
+func newProgram(code string) *protoeval.Value {
+	return &protoeval.Value{
+		Value: &protoeval.Value_Program_{
+			Program: &protoeval.Value_Program{Code: code},
+		},
+	}
+}
+
+// newMessage returns a Finding with the given message, packed into an Any
+// message.
+func newMessage(t *testing.T, message string) *anypb.Any {
+	t.Helper()
+	result, err := anypb.New(&Finding{Message: message})
+	if err != nil {
+		t.Fatalf("create message: %s", err)
+	}
+	return result
+}
+
+// resultResponse returns an EvalResponse with the given result.
+func resultResponse(result interface{}) *EvalResponse {
+	v, err := structpb.NewValue(result)
+	if err != nil {
+		panic(err)
+	}
+	return &EvalResponse{Outcome: &EvalResponse_Result{Result: v}}
+}
+
+// TestEval tests single evaluations.
+func TestEval(t *testing.T) {
+	env := protoeval.NewEnv()
+	if err := env.Set("prefix", "ab"); err != nil {
+		t.Fatal(err)
+	}
+	client := newClient(t, NewServer(env))
+	ctx := context.Background()
+	for _, test := range []struct {
+		name string
+		req  *EvalRequest
+		resp *EvalResponse
+	}{
+		{
+			name: "server env",
+			req: &EvalRequest{
+				Value:   newProgram("scope.value.message.startsWith(env['prefix'])"),
+				Message: newMessage(t, "abc"),
+			},
+			resp: resultResponse(true),
+		},
+		{
+			name: "request env",
+			req: &EvalRequest{
+				Value:   newProgram("scope.value.message.startsWith(env['prefix'])"),
+				Message: newMessage(t, "xyz"),
+				Env: map[string]*structpb.Value{
+					"prefix": structpb.NewStringValue("xy"),
+				},
+			},
+			resp: resultResponse(true),
+		},
+		{
+			name: "args",
+			req: &EvalRequest{
+				Value:   newProgram("[args[0], args[1] + 1.0]"),
+				Message: newMessage(t, ""),
+				Args: []*structpb.Value{
+					structpb.NewStringValue("a"),
+					structpb.NewNumberValue(1),
+				},
+			},
+			resp: resultResponse([]interface{}{"a", 2}),
+		},
+		{
+			name: "message result",
+			req: &EvalRequest{
+				Value:   newProgram("scope.value"),
+				Message: newMessage(t, "abc"),
+			},
+			resp: resultResponse(map[string]interface{}{"message": "abc"}),
+		},
+		{
+			name: "eval error",
+			req: &EvalRequest{
+				Value:   newProgram("env['prefix']"),
+				Message: newMessage(t, "abc"),
+				Env: map[string]*structpb.Value{
+					"prefix": structpb.NewNullValue(),
+				},
+			},
+			resp: &EvalResponse{
+				Outcome: &EvalResponse_Error{Error: &EvalError{}},
+			},
+		},
+		{
+			name: "fail",
+			req: &EvalRequest{
+				Value: &protoeval.Value{
+					Value: &protoeval.Value_Fail_{
+						Fail: &protoeval.Value_Fail{Code: "E1"},
+					},
+				},
+				Message: newMessage(t, "abc"),
+			},
+			resp: &EvalResponse{
+				Outcome: &EvalResponse_Error{Error: &EvalError{FailCode: "E1"}},
+			},
+		},
+	} {
+		resp, err := client.Eval(ctx, test.req)
+		if err != nil {
+			t.Errorf("%s: %s", test.name, err)
+			continue
+		}
+		if x, ok := resp.Outcome.(*EvalResponse_Error); ok {
+			if x.Error.Message == "" {
+				t.Errorf("%s: error message missing", test.name)
+			}
+			x.Error.Message = ""
+		}
+		if !proto.Equal(resp, test.resp) {
+			t.Errorf("%s: expected %s, got %s", test.name, test.resp, resp)
+		}
+	}
+}
+
+// TestEvalTooLong tests that aborted evaluations are reported.
+func TestEvalTooLong(t *testing.T) {
+	client := newClient(t, NewServer(protoeval.NewEnv().SetEvalMax(0)))
+	resp, err := client.Eval(context.Background(), &EvalRequest{
+		Value:   newProgram("true"),
+		Message: newMessage(t, ""),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !resp.GetError().GetTooLong() {
+		t.Errorf("expected too long error, got %s", resp)
+	}
+}
+
+// TestEvalInvalid tests that malformed requests are rejected.
+func TestEvalInvalid(t *testing.T) {
+	client := newClient(t, NewServer(nil))
+	ctx := context.Background()
+	for _, test := range []struct {
+		name string
+		req  *EvalRequest
+	}{
+		{
+			name: "value missing",
+			req:  &EvalRequest{Message: newMessage(t, "")},
+		},
+		{
+			name: "message missing",
+			req:  &EvalRequest{Value: newProgram("true")},
+		},
+		{
+			name: "unknown message type",
+			req: &EvalRequest{
+				Value:   newProgram("true"),
+				Message: &anypb.Any{TypeUrl: "type.googleapis.com/no.Such"},
+			},
+		},
+	} {
+		_, err := client.Eval(ctx, test.req)
+		if status.Code(err) != codes.InvalidArgument {
+			t.Errorf("%s: expected invalid argument error, got %v", test.name, err)
+		}
+	}
+}
+
+// TestEvalRule tests evaluations of preloaded rules.
+func TestEvalRule(t *testing.T) {
+	client := newClient(t, NewServer(nil).SetRule("message",
+		newProgram("scope.value.message")))
+	ctx := context.Background()
+	resp, err := client.Eval(ctx, &EvalRequest{
+		Rule:    "message",
+		Message: newMessage(t, "abc"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !proto.Equal(resp, resultResponse("abc")) {
+		t.Errorf("unexpected response %s", resp)
+	}
+	_, err = client.Eval(ctx, &EvalRequest{
+		Rule:    "nope",
+		Message: newMessage(t, "abc"),
+	})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("expected not found error, got %v", err)
+	}
+	_, err = client.Eval(ctx, &EvalRequest{
+		Value:   newProgram("true"),
+		Rule:    "message",
+		Message: newMessage(t, "abc"),
+	})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected invalid argument error, got %v", err)
+	}
+}
+
+// TestBatchEval tests batch evaluations.
+func TestBatchEval(t *testing.T) {
+	client := newClient(t, NewServer(nil))
+	ctx := context.Background()
+	resp, err := client.BatchEval(ctx, &BatchEvalRequest{
+		Requests: []*EvalRequest{
+			{Message: newMessage(t, "a")},
+			{Value: newProgram("size(scope.value.message)"),
+				Message: newMessage(t, "abc")},
+			{Value: newProgram("nope"), Message: newMessage(t, "a")},
+		},
+		Value: newProgram("scope.value.message"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Responses) != 3 {
+		t.Fatalf("expected 3 responses, got %d", len(resp.Responses))
+	}
+	expected := []*EvalResponse{resultResponse("a"), resultResponse(3)}
+	for i, e := range expected {
+		if !proto.Equal(resp.Responses[i], e) {
+			t.Errorf("response %d: expected %s, got %s", i, e, resp.Responses[i])
+		}
+	}
+	if resp.Responses[2].GetError() == nil {
+		t.Errorf("response 2: expected error, got %s", resp.Responses[2])
+	}
+	_, err = client.BatchEval(ctx, &BatchEvalRequest{
+		Requests: []*EvalRequest{{Message: newMessage(t, "a")}},
+	})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected invalid argument error, got %v", err)
+	}
+}
+
+// countdownContext is a context which is done after its Err method has been
+// called a given number of times.
+type countdownContext struct {
+	context.Context
+
+	// left is the number of Err calls left before the context is done.
+	left int
+}
+
+// Err implements context.Context.Err.
+func (c *countdownContext) Err() error {
+	if c.left == 0 {
+		return context.Canceled
+	}
+	c.left--
+	return nil
+}
+
+// TestEvalContextDone tests that evaluations stop once the context is done.
+func TestEvalContextDone(t *testing.T) {
+	server := NewServer(nil)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	req := &EvalRequest{
+		Value:   newProgram("scope.value.message"),
+		Message: newMessage(t, "a"),
+	}
+	if _, err := server.Eval(ctx, req); status.Code(err) != codes.Canceled {
+		t.Errorf("expected canceled error, got %v", err)
+	}
+	ctx, cancel = context.WithTimeout(context.Background(), 0)
+	defer cancel()
+	_, err := server.Eval(ctx, req)
+	if status.Code(err) != codes.DeadlineExceeded {
+		t.Errorf("expected deadline exceeded error, got %v", err)
+	}
+	_, err = server.BatchEval(&countdownContext{
+		Context: context.Background(),
+		left:    1,
+	}, &BatchEvalRequest{
+		Requests: []*EvalRequest{req, req},
+	})
+	if status.Code(err) != codes.Canceled {
+		t.Errorf("expected canceled error, got %v", err)
+	}
+}
+
+// TestValidate tests Value validation.
+func TestValidate(t *testing.T) {
+	client := newClient(t, NewServer(nil))
+	ctx := context.Background()
+	resp, err := client.Validate(ctx, &ValidateRequest{
+		Value: newProgram("1 + 1"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !resp.Valid || len(resp.Findings) != 0 {
+		t.Errorf("expected valid Value without findings, got %s", resp)
+	}
+	resp, err = client.Validate(ctx, &ValidateRequest{
+		Value: &protoeval.Value{
+			Value: &protoeval.Value_Seq{
+				Seq: &protoeval.Value_ValueList{
+					Values: []*protoeval.Value{newProgram("1 +")},
+				},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Valid {
+		t.Error("expected invalid Value")
+	}
+	ids := make(map[string]Severity)
+	for _, finding := range resp.Findings {
+		ids[finding.RuleId] = finding.Severity
+	}
+	if ids["cel-syntax"] != Severity_SEVERITY_ERROR ||
+		ids["seq-single"] != Severity_SEVERITY_INFO {
+		t.Errorf("unexpected findings %s", resp.Findings)
+	}
+	_, err = client.Validate(ctx, &ValidateRequest{})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected invalid argument error, got %v", err)
+	}
+}
@@ -0,0 +1,76 @@
+package protoeval
+
+import (
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// TestExtensionScope tests scoping into an extension field.
+func TestExtensionScope(t *testing.T) {
+	testmsg := &ExtTest{}
+	proto.SetExtension(testmsg, E_AnExtScalar, int32(42))
+	env := NewEnv()
+	if _, err := evalJSON(env, &ExtTest{}, `
+    { "scope": ["(com.github.thecount.protoeval.an_ext_scalar)"] }
+  `); err == nil {
+		t.Error("expected error selecting unset extension")
+	}
+	if _, err := evalJSON(env, testmsg, `
+    { "scope": ["(com.github.thecount.protoeval.does_not_exist)"] }
+  `); err == nil {
+		t.Error("expected error selecting nonexistent extension")
+	}
+	for _, path := range []string{
+		`["(com.github.thecount.protoeval.an_ext_scalar)"]`,
+		`[100]`,
+	} {
+		result, err := evalJSON(env, testmsg, `{ "scope": `+path+` }`)
+		if err != nil {
+			t.Fatalf("eval %s: %s", path, err)
+		}
+		scalar, ok := result.(int64) // cel type
+		if !ok {
+			t.Fatalf("result type %T is not int64", result)
+		}
+		if scalar != 42 {
+			t.Errorf("expected 42, got %d", scalar)
+		}
+	}
+}
+
+// TestExtensionMessage tests setting extension fields in a message value.
+func TestExtensionMessage(t *testing.T) {
+	env := NewEnv()
+	result, err := evalJSON(env, &ScopeTest{}, `
+    {
+      "message": {
+        "type": "com.github.thecount.protoeval.ExtTest",
+        "fields": {
+          "a_scalar": { "int": 1 },
+          "(com.github.thecount.protoeval.an_ext_scalar)": { "int": 2 },
+          "(com.github.thecount.protoeval.an_ext_list)": { "list": {
+            "kind": "STRING",
+            "values": [
+              { "basic_value": "x" },
+              { "basic_value": "y" }
+            ]
+          }}
+        }
+      }
+    }
+  `)
+	if err != nil {
+		t.Fatalf("eval: %s", err)
+	}
+	msg, ok := result.(*ExtTest)
+	if !ok {
+		t.Fatalf("result type %T is not *ExtTest", result)
+	}
+	expected := &ExtTest{AScalar: proto.Int32(1)}
+	proto.SetExtension(expected, E_AnExtScalar, int32(2))
+	proto.SetExtension(expected, E_AnExtList, []string{"x", "y"})
+	if !proto.Equal(expected, msg) {
+		t.Errorf("expected %v, got %v", expected, msg)
+	}
+}
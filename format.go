@@ -0,0 +1,360 @@
+package protoeval
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Format returns the given Value in a canonical JSON format suitable for rule
+// files under version control. The format is stable: Values which differ
+// only in formatting yield the same output. In particular:
+//
+//   - fields are written with their protobuf names, in field number order,
+//     one field per line, indented by two spaces;
+//   - map entries and JSON object members are written in ascending key order;
+//   - CEL programs spanning several lines are written with one lines entry
+//     per line, and single line programs are written as code;
+//   - scope paths and other lists of scalars are written on a single line;
+//   - numbers are written in their shortest form.
+//
+// Apart from the form of CEL programs, Format changes the layout only. Scope
+// path steps are kept as given, since whether two steps are equivalent
+// (e. g., 5 and "5" as map keys) depends on the message type, which Format
+// does not know.
+//
+// The output can be decoded with protojson and yields a Value equivalent to
+// the given one. Messages embedded in value which cannot be encoded with
+// protojson (e. g., an Any message with an unknown type) are written field by
+// field instead.
+func Format(value *Value) []byte {
+	if value == nil {
+		return []byte("{}\n")
+	}
+	value = proto.Clone(value).(*Value)
+	walkValues(value, "", 0, func(v *Value, _ string, _ int) {
+		if x, ok := v.Value.(*Value_Program_); ok {
+			normalizeProgram(x.Program)
+		}
+	})
+	var w jsonWriter
+	w.writeValue(value, "")
+	w.sb.WriteString("\n")
+	return []byte(w.sb.String())
+}
+
+// normalizeProgram normalizes the given program such that multiline
+// programs use lines with one entry per line, and single line programs use
+// code. A program with both code and lines (which is invalid) is left
+// unchanged.
+func normalizeProgram(program *Value_Program) {
+	if program.Code != "" && len(program.Lines) != 0 {
+		return
+	}
+	code := program.Code
+	if len(program.Lines) != 0 {
+		code = strings.Join(program.Lines, "\n")
+	}
+	lines := strings.Split(code, "\n")
+	if len(lines) == 1 {
+		program.Code, program.Lines = code, nil
+	} else {
+		program.Code, program.Lines = "", lines
+	}
+}
+
+// jsonWriter writes Value trees as indented JSON.
+type jsonWriter struct {
+	// sb receives the output.
+	sb strings.Builder
+
+	// escape, if not nil, escapes text before it is written.
+	escape func(string) string
+
+	// decorate, if not nil, returns a prefix and a suffix written around
+	// the given Value node. The prefix and the suffix are not escaped.
+	decorate func(value *Value) (prefix, suffix string)
+}
+
+// writeText writes the given text, escaping it if necessary.
+func (w *jsonWriter) writeText(text string) {
+	if w.escape != nil {
+		text = w.escape(text)
+	}
+	w.sb.WriteString(text)
+}
+
+// writeValue writes the given Value node.
+func (w *jsonWriter) writeValue(value *Value, indent string) {
+	var suffix string
+	if w.decorate != nil {
+		var prefix string
+		prefix, suffix = w.decorate(value)
+		w.sb.WriteString(prefix)
+	}
+	w.writeMessage(value.ProtoReflect(), indent)
+	w.sb.WriteString(suffix)
+}
+
+// writeMessage writes the given message. Messages not nested in Value are
+// written in their protojson encoding, if possible.
+func (w *jsonWriter) writeMessage(msg protoreflect.Message, indent string) {
+	if !isValueNested(msg.Descriptor()) {
+		data, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(
+			msg.Interface())
+		if err == nil {
+			dec := json.NewDecoder(bytes.NewReader(data))
+			dec.UseNumber()
+			var v interface{}
+			if err = dec.Decode(&v); err == nil {
+				w.writeJSON(v, indent)
+				return
+			}
+		}
+	}
+	fds := msg.Descriptor().Fields()
+	fields := make([]protoreflect.FieldDescriptor, 0, fds.Len())
+	for i := 0; i < fds.Len(); i++ {
+		if fd := fds.Get(i); msg.Has(fd) {
+			fields = append(fields, fd)
+		}
+	}
+	if len(fields) == 0 {
+		w.sb.WriteString("{}")
+		return
+	}
+	sort.Slice(fields, func(i, j int) bool {
+		return fields[i].Number() < fields[j].Number()
+	})
+	inner := indent + "  "
+	w.sb.WriteString("{")
+	for i, fd := range fields {
+		if i > 0 {
+			w.sb.WriteString(",")
+		}
+		w.sb.WriteString("\n" + inner)
+		w.writeText(quoteJSON(string(fd.Name())) + ": ")
+		switch {
+		case fd.IsList():
+			w.writeList(msg.Get(fd).List(), fd, inner)
+		case fd.IsMap():
+			w.writeMap(msg.Get(fd).Map(), fd, inner)
+		default:
+			w.writeSingular(msg.Get(fd), fd, inner)
+		}
+	}
+	w.sb.WriteString("\n" + indent + "}")
+}
+
+// writeList writes the given list of values of the given field.
+func (w *jsonWriter) writeList(
+	list protoreflect.List, fd protoreflect.FieldDescriptor, indent string,
+) {
+	if fd.Message() == nil {
+		elts := make([]string, list.Len())
+		for i := range elts {
+			elts[i] = formatScalarJSON(list.Get(i), fd)
+		}
+		w.writeText("[" + strings.Join(elts, ", ") + "]")
+		return
+	}
+	inner := indent + "  "
+	w.sb.WriteString("[")
+	for i := 0; i < list.Len(); i++ {
+		if i > 0 {
+			w.sb.WriteString(",")
+		}
+		w.sb.WriteString("\n" + inner)
+		w.writeSingular(list.Get(i), fd, inner)
+	}
+	w.sb.WriteString("\n" + indent + "]")
+}
+
+// writeMap writes the given map of the given field in ascending key order.
+func (w *jsonWriter) writeMap(
+	mp protoreflect.Map, fd protoreflect.FieldDescriptor, indent string,
+) {
+	keys := sortedMapKeys(mp)
+	if len(keys) == 0 {
+		w.sb.WriteString("{}")
+		return
+	}
+	inner := indent + "  "
+	w.sb.WriteString("{")
+	for i, key := range keys {
+		if i > 0 {
+			w.sb.WriteString(",")
+		}
+		w.sb.WriteString("\n" + inner)
+		w.writeText(quoteJSON(key.String()) + ": ")
+		w.writeSingular(mp.Get(key), fd.MapValue(), inner)
+	}
+	w.sb.WriteString("\n" + indent + "}")
+}
+
+// writeSingular writes a single value of the given field.
+func (w *jsonWriter) writeSingular(
+	val protoreflect.Value, fd protoreflect.FieldDescriptor, indent string,
+) {
+	switch {
+	case fd.Message() == nil:
+		w.writeText(formatScalarJSON(val, fd))
+	case isValueDescriptor(fd.Message()):
+		w.writeValue(val.Message().Interface().(*Value), indent)
+	default:
+		w.writeMessage(val.Message(), indent)
+	}
+}
+
+// writeJSON writes the given decoded JSON value. Objects are written with
+// their members in ascending key order. Lists of scalars (and lists of lists
+// of scalars) are written on a single line.
+func (w *jsonWriter) writeJSON(v interface{}, indent string) {
+	inner := indent + "  "
+	switch x := v.(type) {
+	case map[string]interface{}:
+		if len(x) == 0 {
+			w.sb.WriteString("{}")
+			return
+		}
+		keys := make([]string, 0, len(x))
+		for key := range x {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		w.sb.WriteString("{")
+		for i, key := range keys {
+			if i > 0 {
+				w.sb.WriteString(",")
+			}
+			w.sb.WriteString("\n" + inner)
+			w.writeText(quoteJSON(key) + ": ")
+			w.writeJSON(x[key], inner)
+		}
+		w.sb.WriteString("\n" + indent + "}")
+	case []interface{}:
+		if isFlatJSONList(x, true) {
+			w.writeText(formatFlatJSONList(x))
+			return
+		}
+		w.sb.WriteString("[")
+		for i, elt := range x {
+			if i > 0 {
+				w.sb.WriteString(",")
+			}
+			w.sb.WriteString("\n" + inner)
+			w.writeJSON(elt, inner)
+		}
+		w.sb.WriteString("\n" + indent + "]")
+	default:
+		w.writeText(formatScalarJSONValue(x))
+	}
+}
+
+// isFlatJSONList reports whether the given decoded JSON list contains only
+// scalars, or, if nested is true, lists of scalars.
+func isFlatJSONList(list []interface{}, nested bool) bool {
+	for _, elt := range list {
+		switch x := elt.(type) {
+		case map[string]interface{}:
+			return false
+		case []interface{}:
+			if !nested || !isFlatJSONList(x, false) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// formatFlatJSONList formats a list for which isFlatJSONList is true.
+func formatFlatJSONList(list []interface{}) string {
+	elts := make([]string, len(list))
+	for i, elt := range list {
+		if x, ok := elt.([]interface{}); ok {
+			elts[i] = formatFlatJSONList(x)
+		} else {
+			elts[i] = formatScalarJSONValue(elt)
+		}
+	}
+	return "[" + strings.Join(elts, ", ") + "]"
+}
+
+// formatScalarJSONValue formats a decoded scalar JSON value. Numbers are
+// written in their shortest form.
+func formatScalarJSONValue(v interface{}) string {
+	switch x := v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return strconv.FormatBool(x)
+	case string:
+		return quoteJSON(x)
+	case json.Number:
+		if f, err := x.Float64(); err == nil && f == math.Trunc(f) &&
+			math.Abs(f) < 1e21 {
+			return strconv.FormatFloat(f, 'f', -1, 64)
+		} else if err == nil {
+			return strconv.FormatFloat(f, 'g', -1, 64)
+		}
+		return x.String()
+	default:
+		panic("BUG: unsupported JSON value type")
+	}
+}
+
+// quoteJSON quotes the given string as JSON string without escaping HTML
+// characters.
+func quoteJSON(s string) string {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(s); err != nil {
+		// Encoding a string cannot fail.
+		panic(err)
+	}
+	return strings.TrimSuffix(buf.String(), "\n")
+}
+
+// formatScalarJSON formats the given scalar value of the given field in the
+// protobuf JSON encoding.
+func formatScalarJSON(
+	val protoreflect.Value, fd protoreflect.FieldDescriptor,
+) string {
+	switch fd.Kind() {
+	case protoreflect.StringKind:
+		return quoteJSON(val.String())
+	case protoreflect.BytesKind:
+		return strconv.Quote(base64.StdEncoding.EncodeToString(val.Bytes()))
+	case protoreflect.EnumKind:
+		if evd := fd.Enum().Values().ByNumber(val.Enum()); evd != nil {
+			return strconv.Quote(string(evd.Name()))
+		}
+		return strconv.Itoa(int(val.Enum()))
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind,
+		protoreflect.Sfixed64Kind, protoreflect.Uint64Kind,
+		protoreflect.Fixed64Kind:
+		return strconv.Quote(val.String())
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		f := val.Float()
+		switch {
+		case math.IsNaN(f):
+			return `"NaN"`
+		case math.IsInf(f, 1):
+			return `"Infinity"`
+		case math.IsInf(f, -1):
+			return `"-Infinity"`
+		}
+		return strconv.FormatFloat(f, 'g', -1, 64)
+	default:
+		return val.String()
+	}
+}
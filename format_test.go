@@ -0,0 +1,113 @@
+package protoeval
+
+import (
+	"testing"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/proto"
+)
+
+// TestFormat tests the canonical format of a Value.
+func TestFormat(t *testing.T) {
+	var value Value
+	err := protojson.Unmarshal([]byte(`{
+    "switch": {"default": {"basic_value": {"b": [1, 2.5], "a": "<&>"}},
+      "cases": [{"then": {"program": {"lines": ["a < b"]}},
+        "case": {"program": {"code": "a &&\n  b"}}}]},
+    "scope": ["items", 0.0, null, ["x", 1]], "args": [{"int": 3}]
+  }`), &value)
+	if err != nil {
+		t.Fatalf("unmarshal value: %s", err)
+	}
+	expected := `{
+  "args": [
+    {
+      "int": "3"
+    }
+  ],
+  "scope": ["items", 0, null, ["x", 1]],
+  "switch": {
+    "cases": [
+      {
+        "case": {
+          "program": {
+            "lines": ["a &&", "  b"]
+          }
+        },
+        "then": {
+          "program": {
+            "code": "a < b"
+          }
+        }
+      }
+    ],
+    "default": {
+      "basic_value": {
+        "a": "<&>",
+        "b": [1, 2.5]
+      }
+    }
+  }
+}
+`
+	formatted := Format(&value)
+	if string(formatted) != expected {
+		t.Fatalf("expected:\n%s\ngot:\n%s", expected, formatted)
+	}
+	var roundTrip Value
+	if err = protojson.Unmarshal(formatted, &roundTrip); err != nil {
+		t.Fatalf("unmarshal formatted value: %s", err)
+	}
+	if again := Format(&roundTrip); string(again) != expected {
+		t.Errorf("formatting is not idempotent:\n%s", again)
+	}
+	if len(value.GetSwitch().Cases[0].Then.GetProgram().Lines) != 1 {
+		t.Error("Format modified its argument")
+	}
+}
+
+// TestFormatEquivalent tests that the formatted Value is equivalent to the
+// original Value, and that equivalent inputs yield the same output.
+func TestFormatEquivalent(t *testing.T) {
+	const text = `
+    message {
+      type: "ScopeTest"
+      fields { key: "b" value { timestamp { seconds: 1 } } }
+      fields { key: "a" value { duration { seconds: 2 nanos: 500 } } }
+    }
+    args { bytes: "\x00\xff" }
+    args { uint: 18446744073709551615 }
+    args { enum { type: "E" name: "X" } }
+    args { default {} }
+    args { basic_message {
+      [type.googleapis.com/google.protobuf.StringValue] { value: "s" }
+    } }
+  `
+	var value Value
+	if err := prototext.Unmarshal([]byte(text), &value); err != nil {
+		t.Fatalf("unmarshal value: %s", err)
+	}
+	formatted := Format(&value)
+	var roundTrip Value
+	if err := protojson.Unmarshal(formatted, &roundTrip); err != nil {
+		t.Fatalf("unmarshal formatted value: %s\n%s", err, formatted)
+	}
+	if !proto.Equal(&value, &roundTrip) {
+		t.Errorf("formatted value differs:\n%s", formatted)
+	}
+	data, err := protojson.MarshalOptions{Multiline: true}.Marshal(&value)
+	if err != nil {
+		t.Fatalf("marshal value: %s", err)
+	}
+	var reordered Value
+	if err = protojson.Unmarshal(data, &reordered); err != nil {
+		t.Fatalf("unmarshal value: %s", err)
+	}
+	if again := Format(&reordered); string(again) != string(formatted) {
+		t.Errorf("expected:\n%s\ngot:\n%s", formatted, again)
+	}
+	if x := string(Format(nil)); x != "{}\n" {
+		t.Errorf("expected {} for nil, got %s", x)
+	}
+}
@@ -5,6 +5,10 @@ go 1.16
 require (
 	github.com/davecgh/go-spew v1.1.0
 	github.com/google/cel-go v0.8.0
+	golang.org/x/net v0.7.0 // indirect
+	golang.org/x/sys v0.5.0 // indirect
+	golang.org/x/text v0.7.0 // indirect
 	google.golang.org/genproto v0.0.0-20210831024726-fe130286e0e2
+	google.golang.org/grpc v1.43.0
 	google.golang.org/protobuf v1.27.1
 )
@@ -0,0 +1,493 @@
+package protoeval
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	exprpb "google.golang.org/genproto/googleapis/api/expr/v1alpha1"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// Severity is the severity of a lint finding.
+type Severity int
+
+const (
+	// SeverityInfo marks findings which are often harmless, but worth a look.
+	SeverityInfo Severity = iota
+
+	// SeverityWarning marks findings which are likely mistakes.
+	SeverityWarning
+
+	// SeverityError marks findings which cause evaluations to fail.
+	SeverityError
+)
+
+// String returns the name of this severity.
+func (s Severity) String() string {
+	switch s {
+	case SeverityInfo:
+		return "info"
+	case SeverityWarning:
+		return "warning"
+	case SeverityError:
+		return "error"
+	default:
+		return fmt.Sprintf("severity(%d)", int(s))
+	}
+}
+
+// LintRule describes a rule checked by Lint.
+type LintRule struct {
+	// ID is the unique rule ID.
+	ID string
+
+	// Severity is the severity of findings of this rule.
+	Severity Severity
+
+	// Description describes what the rule checks.
+	Description string
+}
+
+// Lint rule IDs.
+const (
+	lintSeqSingle         = "seq-single"
+	lintAllOfTrue         = "all-of-true"
+	lintAnyOfFalse        = "any-of-false"
+	lintDropArgsExcess    = "drop-args-excess"
+	lintStoreUnused       = "store-unused"
+	lintProcShadowsStore  = "proc-shadows-store"
+	lintDumpCall          = "dump-call"
+	lintSwitchUnreachable = "switch-unreachable"
+	lintCELSyntax         = "cel-syntax"
+)
+
+// lintRules are the rules checked by Lint.
+var lintRules = []LintRule{
+	{lintSeqSingle, SeverityInfo,
+		"seq with a single element, which can be used directly"},
+	{lintAllOfTrue, SeverityWarning,
+		"all_of element which is always true"},
+	{lintAnyOfFalse, SeverityWarning,
+		"any_of element which is always false"},
+	{lintDropArgsExcess, SeverityWarning,
+		"drop_args exceeding the arguments pushed by enclosing Values " +
+			"(arguments passed to Eval are not counted)"},
+	{lintStoreUnused, SeverityInfo,
+		"store key which is never loaded within the Value"},
+	{lintProcShadowsStore, SeverityWarning,
+		"proc key which is also used to store a value"},
+	{lintDumpCall, SeverityWarning,
+		"CEL program calling dump(), which is meant for debugging"},
+	{lintSwitchUnreachable, SeverityWarning,
+		"switch case or default after a case which is always true"},
+	{lintCELSyntax, SeverityError,
+		"CEL program with a syntax error"},
+}
+
+// LintRules returns the rules checked by Lint.
+func LintRules() []LintRule {
+	return append([]LintRule(nil), lintRules...)
+}
+
+// lintSeverity returns the severity of the rule with the given ID.
+func lintSeverity(id string) Severity {
+	for _, rule := range lintRules {
+		if rule.ID == id {
+			return rule.Severity
+		}
+	}
+	panic(fmt.Sprintf("BUG: unknown lint rule %s", id))
+}
+
+// LintFinding describes a problem found by Lint.
+type LintFinding struct {
+	// RuleID is the ID of the rule which found the problem.
+	RuleID string
+
+	// Severity is the severity of the problem.
+	Severity Severity
+
+	// Path is the path of the Value node with the problem, as in
+	// CoverageNode.Path.
+	Path string
+
+	// Message describes the problem.
+	Message string
+}
+
+// String formats this finding for display.
+func (f LintFinding) String() string {
+	return fmt.Sprintf("%s: %s: %s [%s]", displayPath(f.Path), f.Severity,
+		f.Message, f.RuleID)
+}
+
+// linter holds the state of a Lint call.
+type linter struct {
+	// findings are the findings so far.
+	findings []LintFinding
+
+	// order maps finding indices to the pre-order index of their Value node.
+	order []int
+
+	// nodes is the number of Value nodes visited so far.
+	nodes int
+
+	// stores are the store Values with a constant key.
+	stores []lintKeyedNode
+
+	// procs are the proc Values with a constant key.
+	procs []lintKeyedNode
+
+	// loaded is the set of constant keys loaded with load or accessed in CEL
+	// programs.
+	loaded map[string]bool
+
+	// dynamicLoad is set if a key might be loaded which is not known before
+	// evaluation.
+	dynamicLoad bool
+}
+
+// lintKeyedNode describes a store or proc Value node with a constant key.
+type lintKeyedNode struct {
+	// key is the constant key.
+	key string
+
+	// path is the path of the node.
+	path string
+
+	// index is the pre-order index of the node.
+	index int
+}
+
+// Lint checks the given Value for common mistakes which do not necessarily
+// cause evaluation errors. The rules checked are described by LintRules.
+// Lint does not check the Value against a message type, and it assumes that
+// no arguments are passed to Eval. The findings are ordered by the position
+// of their Value node in the Value tree (in depth-first pre-order).
+func Lint(value *Value) []LintFinding {
+	if value == nil {
+		return nil
+	}
+	l := &linter{
+		loaded: make(map[string]bool),
+	}
+	l.lintValue(value, "", 0)
+	for _, node := range l.procs {
+		for _, store := range l.stores {
+			if store.key == node.key {
+				l.report(node.index, lintProcShadowsStore, node.path,
+					"proc key %q shadows the value stored at %s", node.key,
+					displayPath(store.path))
+				break
+			}
+		}
+	}
+	if !l.dynamicLoad {
+		for _, node := range l.stores {
+			if !l.loaded[node.key] {
+				l.report(node.index, lintStoreUnused, node.path,
+					"stored key %q is never loaded", node.key)
+			}
+		}
+	}
+	indices := make([]int, len(l.findings))
+	for i := range indices {
+		indices[i] = i
+	}
+	sort.SliceStable(indices, func(i, j int) bool {
+		return l.order[indices[i]] < l.order[indices[j]]
+	})
+	findings := make([]LintFinding, len(indices))
+	for i, index := range indices {
+		findings[i] = l.findings[index]
+	}
+	return findings
+}
+
+// displayPath returns path for display.
+func displayPath(path string) string {
+	if path == "" {
+		return "<root>"
+	}
+	return path
+}
+
+// report adds a finding for the Value node with the given pre-order index.
+func (l *linter) report(
+	index int, id, path, format string, args ...interface{},
+) {
+	l.findings = append(l.findings, LintFinding{
+		RuleID:   id,
+		Severity: lintSeverity(id),
+		Path:     path,
+		Message:  fmt.Sprintf(format, args...),
+	})
+	l.order = append(l.order, index)
+}
+
+// lintElementArgs maps child paths of Value nodes to the number of arguments
+// pushed for the evaluation of the child.
+var lintElementArgs = map[string]int{
+	"range.value":     2,
+	"transform.value": 2,
+	"filter.value":    2,
+	"count.value":     2,
+	"sum.value":       2,
+	"min.value":       2,
+	"max.value":       2,
+	"fold.value":      3,
+	"group_by.key":    2,
+	"group_by.value":  2,
+	"try.fallback":    1,
+}
+
+// childArgs returns the number of arguments available to the given child of
+// a Value node, given the number of arguments available to the kind of the
+// node. A negative number means the number of arguments is unknown.
+func childArgs(path string, available int) int {
+	switch {
+	case available < 0, path == "parent", path == "proc.value":
+		return -1
+	case strings.HasPrefix(path, "sort_by.keys[") &&
+		strings.HasSuffix(path, "].value"):
+		return available + 2
+	default:
+		return available + lintElementArgs[path]
+	}
+}
+
+// lintValue lints the given Value node at the given path. available is the
+// number of arguments available to the node, or negative if unknown.
+func (l *linter) lintValue(value *Value, path string, available int) {
+	index := l.nodes
+	l.nodes++
+	if value.DropArgs > 0 && available >= 0 {
+		if int(value.DropArgs) > available {
+			l.report(index, lintDropArgsExcess, path,
+				"drop_args %d exceeds the %d arguments pushed by enclosing Values",
+				value.DropArgs, available)
+			available = 0
+		} else {
+			available -= int(value.DropArgs)
+		}
+	}
+	l.lintKind(value, path, index)
+	// Value.args are evaluated in reverse order, each with the arguments
+	// pushed by the args after it.
+	for i, arg := range value.Args {
+		argAvailable := -1
+		if available >= 0 {
+			argAvailable = available + len(value.Args) - 1 - i
+		}
+		l.lintValue(arg, joinPath(path, fmt.Sprintf("args[%d]", i)),
+			argAvailable)
+	}
+	if available >= 0 {
+		available += len(value.Args)
+	}
+	for _, child := range childValues(value) {
+		if strings.HasPrefix(child.path, "args[") {
+			continue
+		}
+		l.lintValue(child.value, joinPath(path, child.path),
+			childArgs(child.path, available))
+	}
+}
+
+// lintKind checks the kind of the given Value node.
+func (l *linter) lintKind(value *Value, path string, index int) {
+	switch x := value.Value.(type) {
+	case *Value_Seq:
+		if len(x.Seq.Values) == 1 {
+			l.report(index, lintSeqSingle, path, "seq has a single element")
+		}
+	case *Value_AllOf:
+		for i, elt := range x.AllOf.Values {
+			if b, ok := constantBool(elt); ok && b {
+				l.report(index, lintAllOfTrue, path,
+					"all_of element %d is always true", i)
+			}
+		}
+	case *Value_AnyOf:
+		for i, elt := range x.AnyOf.Values {
+			if b, ok := constantBool(elt); ok && !b {
+				l.report(index, lintAnyOfFalse, path,
+					"any_of element %d is always false", i)
+			}
+		}
+	case *Value_Switch_:
+		for i, cse := range x.Switch.Cases {
+			if b, ok := constantBool(cse.Case); !ok || !b {
+				continue
+			}
+			for j := i + 1; j < len(x.Switch.Cases); j++ {
+				l.report(index, lintSwitchUnreachable, path,
+					"switch case %d is unreachable since case %d is always true", j, i)
+			}
+			if x.Switch.Default != nil {
+				l.report(index, lintSwitchUnreachable, path,
+					"switch default is unreachable since case %d is always true", i)
+			}
+			break
+		}
+	case *Value_Store:
+		if key, ok := constantString(x.Store.Key); ok {
+			l.stores = append(l.stores, lintKeyedNode{key, path, index})
+		}
+	case *Value_Proc:
+		if key, ok := constantString(x.Proc.Key); ok {
+			l.procs = append(l.procs, lintKeyedNode{key, path, index})
+		}
+	case *Value_Load:
+		if key, ok := constantString(x.Load); ok {
+			l.loaded[key] = true
+		} else {
+			l.dynamicLoad = true
+		}
+	case *Value_Program_:
+		expr, err := parseProgram(x.Program)
+		if err != nil {
+			l.report(index, lintCELSyntax, path, "%s",
+				strings.TrimSpace(err.Error()))
+			return
+		}
+		if l.lintExpr(expr) {
+			l.report(index, lintDumpCall, path, "CEL program calls dump()")
+		}
+	}
+}
+
+// lintExpr records the environment keys accessed by the given CEL
+// expression, and reports whether the expression calls dump.
+func (l *linter) lintExpr(expr *exprpb.Expr) (dump bool) {
+	var visit func(expr *exprpb.Expr)
+	visit = func(expr *exprpb.Expr) {
+		if expr == nil {
+			return
+		}
+		switch x := expr.ExprKind.(type) {
+		case *exprpb.Expr_IdentExpr:
+			if x.IdentExpr.Name == "env" {
+				l.dynamicLoad = true
+			}
+		case *exprpb.Expr_SelectExpr:
+			if isIdent(x.SelectExpr.Operand, "env") {
+				l.loaded[x.SelectExpr.Field] = true
+				return
+			}
+			visit(x.SelectExpr.Operand)
+		case *exprpb.Expr_CallExpr:
+			call := x.CallExpr
+			if call.Function == "dump" {
+				dump = true
+			}
+			if call.Function == "_[_]" && len(call.Args) == 2 &&
+				isIdent(call.Args[0], "env") {
+				if c := call.Args[1].GetConstExpr(); c != nil {
+					if s, ok := c.ConstantKind.(*exprpb.Constant_StringValue); ok {
+						l.loaded[s.StringValue] = true
+						return
+					}
+				}
+			}
+			visit(call.Target)
+			for _, arg := range call.Args {
+				visit(arg)
+			}
+		case *exprpb.Expr_ListExpr:
+			for _, elt := range x.ListExpr.Elements {
+				visit(elt)
+			}
+		case *exprpb.Expr_StructExpr:
+			for _, entry := range x.StructExpr.Entries {
+				visit(entry.GetMapKey())
+				visit(entry.Value)
+			}
+		case *exprpb.Expr_ComprehensionExpr:
+			c := x.ComprehensionExpr
+			for _, e := range []*exprpb.Expr{
+				c.IterRange, c.AccuInit, c.LoopCondition, c.LoopStep, c.Result,
+			} {
+				visit(e)
+			}
+		}
+	}
+	visit(expr)
+	return dump
+}
+
+// isIdent reports whether expr is the identifier with the given name.
+func isIdent(expr *exprpb.Expr, name string) bool {
+	ident := expr.GetIdentExpr()
+	return ident != nil && ident.Name == name
+}
+
+// programCode returns the code of the given program.
+func programCode(program *Value_Program) string {
+	if program.Code != "" {
+		return program.Code
+	}
+	return strings.Join(program.Lines, "\n")
+}
+
+// parseProgram parses the code of the given program.
+func parseProgram(program *Value_Program) (*exprpb.Expr, error) {
+	initCel()
+	ast, iss := commonCelEnv.Parse(programCode(program))
+	if err := iss.Err(); err != nil {
+		return nil, err
+	}
+	return ast.Expr(), nil
+}
+
+// isPlain reports whether value is evaluated without changing the scope or
+// the arguments.
+func isPlain(value *Value) bool {
+	return value != nil && value.DropArgs == 0 && len(value.Args) == 0 &&
+		value.Scope == nil
+}
+
+// constantExpr returns the constant yielded by value, if value is a plain
+// basic value or a CEL program consisting of a single literal.
+func constantExpr(value *Value) (*structpb.Value, *exprpb.Constant) {
+	if !isPlain(value) {
+		return nil, nil
+	}
+	switch x := value.Value.(type) {
+	case *Value_BasicValue:
+		return x.BasicValue, nil
+	case *Value_Program_:
+		expr, err := parseProgram(x.Program)
+		if err != nil {
+			return nil, nil
+		}
+		return nil, expr.GetConstExpr()
+	default:
+		return nil, nil
+	}
+}
+
+// constantBool returns the boolean constant yielded by value, if any.
+func constantBool(value *Value) (result, ok bool) {
+	basic, constant := constantExpr(value)
+	if b, ok := basic.GetKind().(*structpb.Value_BoolValue); ok {
+		return b.BoolValue, true
+	}
+	if b, ok := constant.GetConstantKind().(*exprpb.Constant_BoolValue); ok {
+		return b.BoolValue, true
+	}
+	return false, false
+}
+
+// constantString returns the string constant yielded by value, if any.
+func constantString(value *Value) (result string, ok bool) {
+	basic, constant := constantExpr(value)
+	if s, ok := basic.GetKind().(*structpb.Value_StringValue); ok {
+		return s.StringValue, true
+	}
+	if s, ok := constant.GetConstantKind().(*exprpb.Constant_StringValue); ok {
+		return s.StringValue, true
+	}
+	return "", false
+}
@@ -0,0 +1,118 @@
+package protoeval
+
+import (
+	"testing"
+
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// TestLint tests the lint rules.
+func TestLint(t *testing.T) {
+	for _, tc := range []struct {
+		value    string
+		expected []string
+	}{
+		{`{ "program": { "code": "1 + 2" } }`, nil},
+		{`{ "seq": { "values": [ { "int": 1 } ] } }`, []string{
+			"<root>: info: seq has a single element [seq-single]",
+		}},
+		{`{ "all_of": { "values": [
+      { "basic_value": true }, { "program": { "code": " true " } },
+      { "scope": [], "basic_value": true }, { "basic_value": false }
+    ] } }`, []string{
+			"<root>: warning: all_of element 0 is always true [all-of-true]",
+			"<root>: warning: all_of element 1 is always true [all-of-true]",
+		}},
+		{`{ "any_of": { "values": [ { "program": { "code": "false" } } ] } }`,
+			[]string{
+				"<root>: warning: any_of element 0 is always false [any-of-false]",
+			}},
+		{`{ "args": [ { "drop_args": 1 }, { "int": 1 } ],
+      "range": { "value": { "drop_args": 4 } } }`, nil},
+		{`{ "args": [ { "int": 1 }, { "drop_args": 1 } ] }`, []string{
+			"args[1]: warning: drop_args 1 exceeds the 0 arguments pushed by " +
+				"enclosing Values [drop-args-excess]",
+		}},
+		{`{ "args": [ { "drop_args": 1 }, { "int": 1 } ],
+      "fold": { "value": { "drop_args": 6 } } }`, []string{
+			"fold.value: warning: drop_args 6 exceeds the 5 arguments " +
+				"pushed by enclosing Values [drop-args-excess]",
+		}},
+		{`{ "parent": { "drop_args": 1 } }`, nil},
+		{`{ "seq": { "values": [
+      { "store": { "key": { "basic_value": "a" }, "value": { "int": 1 } } },
+      { "store": { "key": { "program": { "code": "'b'" } },
+        "value": { "int": 2 } } },
+      { "store": { "key": { "basic_value": "c" }, "value": { "int": 3 } } },
+      { "load": { "basic_value": "a" } },
+      { "program": { "code": "env.c + 1" } }
+    ] } }`, []string{
+			"seq.values[1]: info: stored key \"b\" is never loaded [store-unused]",
+		}},
+		{`{ "seq": { "values": [
+      { "store": { "key": { "basic_value": "a" }, "value": { "int": 1 } } },
+      { "program": { "code": "size(env)" } }
+    ] } }`, nil},
+		{`{ "seq": { "values": [
+      { "store": { "key": { "basic_value": "a" }, "value": { "int": 1 } } },
+      { "proc": { "key": { "basic_value": "a" }, "value": { "int": 2 } } },
+      { "load": { "basic_value": "a" } }
+    ] } }`, []string{
+			"seq.values[1]: warning: proc key \"a\" shadows the value stored at " +
+				"seq.values[0] [proc-shadows-store]",
+		}},
+		{`{ "program": { "lines": [ "[1, 2].map(x,", "  x.dump())" ] } }`,
+			[]string{
+				"<root>: warning: CEL program calls dump() [dump-call]",
+			}},
+		{`{ "program": { "code": "'dump()'" } }`, nil},
+		{`{ "program": { "code": "1 +" } }`, []string{"cel-syntax"}},
+		{`{ "switch": { "cases": [
+      { "case": { "program": { "code": "scope.value" } }, "then": { "int": 1 } },
+      { "case": { "basic_value": true }, "then": { "int": 2 } },
+      { "case": { "basic_value": true }, "then": { "int": 3 } }
+    ], "default": { "int": 4 } } }`, []string{
+			"<root>: warning: switch case 2 is unreachable since case 1 is " +
+				"always true [switch-unreachable]",
+			"<root>: warning: switch default is unreachable since case 1 is " +
+				"always true [switch-unreachable]",
+		}},
+	} {
+		var value Value
+		if err := protojson.Unmarshal([]byte(tc.value), &value); err != nil {
+			t.Errorf("unmarshal %s: %s", tc.value, err)
+			continue
+		}
+		findings := Lint(&value)
+		if len(findings) != len(tc.expected) {
+			t.Errorf("lint %s: expected %d findings, got %v", tc.value,
+				len(tc.expected), findings)
+			continue
+		}
+		for i, finding := range findings {
+			if tc.expected[i] == "cel-syntax" {
+				if finding.RuleID != "cel-syntax" ||
+					finding.Severity != SeverityError {
+					t.Errorf("lint %s: expected syntax error, got %s", tc.value, finding)
+				}
+			} else if finding.String() != tc.expected[i] {
+				t.Errorf("lint %s: expected %s, got %s", tc.value, tc.expected[i],
+					finding)
+			}
+		}
+	}
+}
+
+// TestLintRules tests that all rules are described.
+func TestLintRules(t *testing.T) {
+	ids := make(map[string]bool)
+	for _, rule := range LintRules() {
+		if rule.ID == "" || rule.Description == "" || ids[rule.ID] {
+			t.Errorf("bad rule %+v", rule)
+		}
+		ids[rule.ID] = true
+	}
+	if len(Lint(nil)) != 0 {
+		t.Error("expected no findings for nil value")
+	}
+}
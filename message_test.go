@@ -0,0 +1,82 @@
+package protoeval
+
+import (
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// TestMessageBase tests constructing a message from a base message.
+func TestMessageBase(t *testing.T) {
+	testmsg := &ScopeTest{
+		AScalar:    42,
+		AList:      []int32{1, 2},
+		AStringMap: map[string]int32{"x": 1},
+		AOneof:     &ScopeTest_AString{AString: "foo"},
+	}
+	env := NewEnv()
+	result, err := evalJSON(env, testmsg, `
+    { "message": {
+      "type": "com.github.thecount.protoeval.ScopeTest",
+      "base": {},
+      "fields": {
+        "a_scalar": { "int": 7 },
+        "an_int": { "int": 3 }
+      },
+      "clear": [["a_list", 0]]
+    } }
+  `)
+	if err != nil {
+		t.Fatalf("eval: %s", err)
+	}
+	expected := &ScopeTest{
+		AScalar:    7,
+		AList:      []int32{2},
+		AStringMap: map[string]int32{"x": 1},
+		AOneof:     &ScopeTest_AnInt{AnInt: 3},
+	}
+	if !proto.Equal(expected, result.(proto.Message)) {
+		t.Errorf("expected %v, got %v", expected, result)
+	}
+	if testmsg.AScalar != 42 || len(testmsg.AList) != 2 {
+		t.Errorf("original message modified: %v", testmsg)
+	}
+	result, err = evalJSON(env, testmsg, `
+    { "message": {
+      "type": "com.github.thecount.protoeval.ScopeTest",
+      "base": { "basic_value": { "a_scalar": 5 } },
+      "fields": { "a_string": { "basic_value": "bar" } }
+    } }
+  `)
+	if err != nil {
+		t.Fatalf("eval map base: %s", err)
+	}
+	expected = &ScopeTest{
+		AScalar: 5,
+		AOneof:  &ScopeTest_AString{AString: "bar"},
+	}
+	if !proto.Equal(expected, result.(proto.Message)) {
+		t.Errorf("expected %v, got %v", expected, result)
+	}
+	for _, value := range []string{
+		`{ "message": {
+      "type": "com.github.thecount.protoeval.ScopeTest",
+      "base": { "basic_value": 1 }
+    } }`,
+		`{ "message": {
+      "type": "com.github.thecount.protoeval.ScopeTest",
+      "base": { "duration": "1s" }
+    } }`,
+		`{ "message": {
+      "type": "com.github.thecount.protoeval.ScopeTest",
+      "fields": {
+        "an_int": { "int": 1 },
+        "a_string": { "basic_value": "x" }
+      }
+    } }`,
+	} {
+		if _, err := evalJSON(env, testmsg, value); err == nil {
+			t.Errorf("expected error with %s", value)
+		}
+	}
+}
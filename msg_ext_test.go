@@ -0,0 +1,192 @@
+// File test_ext.proto defines protobuf messages with extensions used for
+// testing the protoeval package. Extensions require proto2 syntax. The
+// messages defined here will not be included in production builds.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.1
+// 	protoc        v3.19.4
+// source: test_ext.proto
+
+package protoeval
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// ExtTest is an extendable message for extension scope selection testing.
+type ExtTest struct {
+	state           protoimpl.MessageState
+	sizeCache       protoimpl.SizeCache
+	unknownFields   protoimpl.UnknownFields
+	extensionFields protoimpl.ExtensionFields
+
+	AScalar *int32 `protobuf:"varint,1,opt,name=a_scalar,json=aScalar" json:"a_scalar,omitempty"`
+}
+
+func (x *ExtTest) Reset() {
+	*x = ExtTest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_test_ext_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExtTest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExtTest) ProtoMessage() {}
+
+func (x *ExtTest) ProtoReflect() protoreflect.Message {
+	mi := &file_test_ext_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExtTest.ProtoReflect.Descriptor instead.
+func (*ExtTest) Descriptor() ([]byte, []int) {
+	return file_test_ext_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ExtTest) GetAScalar() int32 {
+	if x != nil && x.AScalar != nil {
+		return *x.AScalar
+	}
+	return 0
+}
+
+var file_test_ext_proto_extTypes = []protoimpl.ExtensionInfo{
+	{
+		ExtendedType:  (*ExtTest)(nil),
+		ExtensionType: (*int32)(nil),
+		Field:         100,
+		Name:          "com.github.thecount.protoeval.an_ext_scalar",
+		Tag:           "varint,100,opt,name=an_ext_scalar",
+		Filename:      "test_ext.proto",
+	},
+	{
+		ExtendedType:  (*ExtTest)(nil),
+		ExtensionType: ([]string)(nil),
+		Field:         101,
+		Name:          "com.github.thecount.protoeval.an_ext_list",
+		Tag:           "bytes,101,rep,name=an_ext_list",
+		Filename:      "test_ext.proto",
+	},
+}
+
+// Extension fields to ExtTest.
+var (
+	// optional int32 an_ext_scalar = 100;
+	E_AnExtScalar = &file_test_ext_proto_extTypes[0]
+	// repeated string an_ext_list = 101;
+	E_AnExtList = &file_test_ext_proto_extTypes[1]
+)
+
+var File_test_ext_proto protoreflect.FileDescriptor
+
+var file_test_ext_proto_rawDesc = []byte{
+	0x0a, 0x0e, 0x74, 0x65, 0x73, 0x74, 0x5f, 0x65, 0x78, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x12, 0x1d, 0x63, 0x6f, 0x6d, 0x2e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x74, 0x68, 0x65,
+	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x65, 0x76, 0x61, 0x6c, 0x22,
+	0x2b, 0x0a, 0x07, 0x45, 0x78, 0x74, 0x54, 0x65, 0x73, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x61, 0x5f,
+	0x73, 0x63, 0x61, 0x6c, 0x61, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x61, 0x53,
+	0x63, 0x61, 0x6c, 0x61, 0x72, 0x2a, 0x05, 0x08, 0x64, 0x10, 0xc8, 0x01, 0x3a, 0x4a, 0x0a, 0x0d,
+	0x61, 0x6e, 0x5f, 0x65, 0x78, 0x74, 0x5f, 0x73, 0x63, 0x61, 0x6c, 0x61, 0x72, 0x12, 0x26, 0x2e,
+	0x63, 0x6f, 0x6d, 0x2e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x74, 0x68, 0x65, 0x63, 0x6f,
+	0x75, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x65, 0x76, 0x61, 0x6c, 0x2e, 0x45, 0x78,
+	0x74, 0x54, 0x65, 0x73, 0x74, 0x18, 0x64, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x61, 0x6e, 0x45,
+	0x78, 0x74, 0x53, 0x63, 0x61, 0x6c, 0x61, 0x72, 0x3a, 0x46, 0x0a, 0x0b, 0x61, 0x6e, 0x5f, 0x65,
+	0x78, 0x74, 0x5f, 0x6c, 0x69, 0x73, 0x74, 0x12, 0x26, 0x2e, 0x63, 0x6f, 0x6d, 0x2e, 0x67, 0x69,
+	0x74, 0x68, 0x75, 0x62, 0x2e, 0x74, 0x68, 0x65, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x65, 0x76, 0x61, 0x6c, 0x2e, 0x45, 0x78, 0x74, 0x54, 0x65, 0x73, 0x74, 0x18,
+	0x65, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x61, 0x6e, 0x45, 0x78, 0x74, 0x4c, 0x69, 0x73, 0x74,
+	0x42, 0x1f, 0x5a, 0x1d, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x54,
+	0x68, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x65, 0x76, 0x61,
+	0x6c,
+}
+
+var (
+	file_test_ext_proto_rawDescOnce sync.Once
+	file_test_ext_proto_rawDescData = file_test_ext_proto_rawDesc
+)
+
+func file_test_ext_proto_rawDescGZIP() []byte {
+	file_test_ext_proto_rawDescOnce.Do(func() {
+		file_test_ext_proto_rawDescData = protoimpl.X.CompressGZIP(file_test_ext_proto_rawDescData)
+	})
+	return file_test_ext_proto_rawDescData
+}
+
+var file_test_ext_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_test_ext_proto_goTypes = []interface{}{
+	(*ExtTest)(nil), // 0: com.github.thecount.protoeval.ExtTest
+}
+var file_test_ext_proto_depIdxs = []int32{
+	0, // 0: com.github.thecount.protoeval.an_ext_scalar:extendee -> com.github.thecount.protoeval.ExtTest
+	0, // 1: com.github.thecount.protoeval.an_ext_list:extendee -> com.github.thecount.protoeval.ExtTest
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	0, // [0:2] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_test_ext_proto_init() }
+func file_test_ext_proto_init() {
+	if File_test_ext_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_test_ext_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExtTest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			case 3:
+				return &v.extensionFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_test_ext_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 2,
+			NumServices:   0,
+		},
+		GoTypes:           file_test_ext_proto_goTypes,
+		DependencyIndexes: file_test_ext_proto_depIdxs,
+		MessageInfos:      file_test_ext_proto_msgTypes,
+		ExtensionInfos:    file_test_ext_proto_extTypes,
+	}.Build()
+	File_test_ext_proto = out.File
+	file_test_ext_proto_rawDesc = nil
+	file_test_ext_proto_goTypes = nil
+	file_test_ext_proto_depIdxs = nil
+}
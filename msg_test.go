@@ -30,14 +30,20 @@ type ScopeTest struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	AScalar    int32            `protobuf:"varint,1,opt,name=a_scalar,json=aScalar,proto3" json:"a_scalar,omitempty"`
-	AList      []int32          `protobuf:"varint,2,rep,packed,name=a_list,json=aList,proto3" json:"a_list,omitempty"`
-	AStringMap map[string]int32 `protobuf:"bytes,3,rep,name=a_string_map,json=aStringMap,proto3" json:"a_string_map,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
-	ABoolMap   map[bool]int32   `protobuf:"bytes,4,rep,name=a_bool_map,json=aBoolMap,proto3" json:"a_bool_map,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
-	AUint32Map map[uint32]int32 `protobuf:"bytes,5,rep,name=a_uint32_map,json=aUint32Map,proto3" json:"a_uint32_map,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
-	AUint64Map map[uint64]int32 `protobuf:"bytes,6,rep,name=a_uint64_map,json=aUint64Map,proto3" json:"a_uint64_map,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
-	AnInt32Map map[int32]int32  `protobuf:"bytes,7,rep,name=an_int32_map,json=anInt32Map,proto3" json:"an_int32_map,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
-	AnInt64Map map[int64]int32  `protobuf:"bytes,8,rep,name=an_int64_map,json=anInt64Map,proto3" json:"an_int64_map,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	AScalar      int32                 `protobuf:"varint,1,opt,name=a_scalar,json=aScalar,proto3" json:"a_scalar,omitempty"`
+	AList        []int32               `protobuf:"varint,2,rep,packed,name=a_list,json=aList,proto3" json:"a_list,omitempty"`
+	AStringMap   map[string]int32      `protobuf:"bytes,3,rep,name=a_string_map,json=aStringMap,proto3" json:"a_string_map,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	ABoolMap     map[bool]int32        `protobuf:"bytes,4,rep,name=a_bool_map,json=aBoolMap,proto3" json:"a_bool_map,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	AUint32Map   map[uint32]int32      `protobuf:"bytes,5,rep,name=a_uint32_map,json=aUint32Map,proto3" json:"a_uint32_map,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	AUint64Map   map[uint64]int32      `protobuf:"bytes,6,rep,name=a_uint64_map,json=aUint64Map,proto3" json:"a_uint64_map,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	AnInt32Map   map[int32]int32       `protobuf:"bytes,7,rep,name=an_int32_map,json=anInt32Map,proto3" json:"an_int32_map,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	AnInt64Map   map[int64]int32       `protobuf:"bytes,8,rep,name=an_int64_map,json=anInt64Map,proto3" json:"an_int64_map,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	AMessageList []*ScopeTest          `protobuf:"bytes,9,rep,name=a_message_list,json=aMessageList,proto3" json:"a_message_list,omitempty"`
+	AMessageMap  map[string]*ScopeTest `protobuf:"bytes,10,rep,name=a_message_map,json=aMessageMap,proto3" json:"a_message_map,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// Types that are assignable to AOneof:
+	//	*ScopeTest_AnInt
+	//	*ScopeTest_AString
+	AOneof isScopeTest_AOneof `protobuf_oneof:"a_oneof"`
 }
 
 func (x *ScopeTest) Reset() {
@@ -128,12 +134,63 @@ func (x *ScopeTest) GetAnInt64Map() map[int64]int32 {
 	return nil
 }
 
+func (x *ScopeTest) GetAMessageList() []*ScopeTest {
+	if x != nil {
+		return x.AMessageList
+	}
+	return nil
+}
+
+func (x *ScopeTest) GetAMessageMap() map[string]*ScopeTest {
+	if x != nil {
+		return x.AMessageMap
+	}
+	return nil
+}
+
+func (m *ScopeTest) GetAOneof() isScopeTest_AOneof {
+	if m != nil {
+		return m.AOneof
+	}
+	return nil
+}
+
+func (x *ScopeTest) GetAnInt() int32 {
+	if x, ok := x.GetAOneof().(*ScopeTest_AnInt); ok {
+		return x.AnInt
+	}
+	return 0
+}
+
+func (x *ScopeTest) GetAString() string {
+	if x, ok := x.GetAOneof().(*ScopeTest_AString); ok {
+		return x.AString
+	}
+	return ""
+}
+
+type isScopeTest_AOneof interface {
+	isScopeTest_AOneof()
+}
+
+type ScopeTest_AnInt struct {
+	AnInt int32 `protobuf:"varint,11,opt,name=an_int,json=anInt,proto3,oneof"`
+}
+
+type ScopeTest_AString struct {
+	AString string `protobuf:"bytes,12,opt,name=a_string,json=aString,proto3,oneof"`
+}
+
+func (*ScopeTest_AnInt) isScopeTest_AOneof() {}
+
+func (*ScopeTest_AString) isScopeTest_AOneof() {}
+
 var File_test_proto protoreflect.FileDescriptor
 
 var file_test_proto_rawDesc = []byte{
 	0x0a, 0x0a, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x1d, 0x63, 0x6f,
 	0x6d, 0x2e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x74, 0x68, 0x65, 0x63, 0x6f, 0x75, 0x6e,
-	0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x65, 0x76, 0x61, 0x6c, 0x22, 0xd7, 0x07, 0x0a, 0x09,
+	0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x65, 0x76, 0x61, 0x6c, 0x22, 0xb1, 0x0a, 0x0a, 0x09,
 	0x53, 0x63, 0x6f, 0x70, 0x65, 0x54, 0x65, 0x73, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x61, 0x5f, 0x73,
 	0x63, 0x61, 0x6c, 0x61, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x61, 0x53, 0x63,
 	0x61, 0x6c, 0x61, 0x72, 0x12, 0x15, 0x0a, 0x06, 0x61, 0x5f, 0x6c, 0x69, 0x73, 0x74, 0x18, 0x02,
@@ -171,33 +228,55 @@ var file_test_proto_rawDesc = []byte{
 	0x74, 0x68, 0x75, 0x62, 0x2e, 0x74, 0x68, 0x65, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x2e, 0x70, 0x72,
 	0x6f, 0x74, 0x6f, 0x65, 0x76, 0x61, 0x6c, 0x2e, 0x53, 0x63, 0x6f, 0x70, 0x65, 0x54, 0x65, 0x73,
 	0x74, 0x2e, 0x41, 0x6e, 0x49, 0x6e, 0x74, 0x36, 0x34, 0x4d, 0x61, 0x70, 0x45, 0x6e, 0x74, 0x72,
-	0x79, 0x52, 0x0a, 0x61, 0x6e, 0x49, 0x6e, 0x74, 0x36, 0x34, 0x4d, 0x61, 0x70, 0x1a, 0x3d, 0x0a,
-	0x0f, 0x41, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x4d, 0x61, 0x70, 0x45, 0x6e, 0x74, 0x72, 0x79,
-	0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b,
+	0x79, 0x52, 0x0a, 0x61, 0x6e, 0x49, 0x6e, 0x74, 0x36, 0x34, 0x4d, 0x61, 0x70, 0x12, 0x4e, 0x0a,
+	0x0e, 0x61, 0x5f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x5f, 0x6c, 0x69, 0x73, 0x74, 0x18,
+	0x09, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x28, 0x2e, 0x63, 0x6f, 0x6d, 0x2e, 0x67, 0x69, 0x74, 0x68,
+	0x75, 0x62, 0x2e, 0x74, 0x68, 0x65, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x65, 0x76, 0x61, 0x6c, 0x2e, 0x53, 0x63, 0x6f, 0x70, 0x65, 0x54, 0x65, 0x73, 0x74, 0x52,
+	0x0c, 0x61, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x5d, 0x0a,
+	0x0d, 0x61, 0x5f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x5f, 0x6d, 0x61, 0x70, 0x18, 0x0a,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x39, 0x2e, 0x63, 0x6f, 0x6d, 0x2e, 0x67, 0x69, 0x74, 0x68, 0x75,
+	0x62, 0x2e, 0x74, 0x68, 0x65, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x65, 0x76, 0x61, 0x6c, 0x2e, 0x53, 0x63, 0x6f, 0x70, 0x65, 0x54, 0x65, 0x73, 0x74, 0x2e, 0x41,
+	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x4d, 0x61, 0x70, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52,
+	0x0b, 0x61, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x4d, 0x61, 0x70, 0x12, 0x17, 0x0a, 0x06,
+	0x61, 0x6e, 0x5f, 0x69, 0x6e, 0x74, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x05, 0x48, 0x00, 0x52, 0x05,
+	0x61, 0x6e, 0x49, 0x6e, 0x74, 0x12, 0x1b, 0x0a, 0x08, 0x61, 0x5f, 0x73, 0x74, 0x72, 0x69, 0x6e,
+	0x67, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x07, 0x61, 0x53, 0x74, 0x72, 0x69,
+	0x6e, 0x67, 0x1a, 0x3d, 0x0a, 0x0f, 0x41, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x4d, 0x61, 0x70,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38,
+	0x01, 0x1a, 0x3b, 0x0a, 0x0d, 0x41, 0x42, 0x6f, 0x6f, 0x6c, 0x4d, 0x61, 0x70, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x3d,
+	0x0a, 0x0f, 0x41, 0x55, 0x69, 0x6e, 0x74, 0x33, 0x32, 0x4d, 0x61, 0x70, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x03,
+	0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x3d, 0x0a,
+	0x0f, 0x41, 0x55, 0x69, 0x6e, 0x74, 0x36, 0x34, 0x4d, 0x61, 0x70, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x03, 0x6b,
 	0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x05, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x3b, 0x0a, 0x0d,
-	0x41, 0x42, 0x6f, 0x6f, 0x6c, 0x4d, 0x61, 0x70, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a,
-	0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12,
-	0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05,
-	0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x3d, 0x0a, 0x0f, 0x41, 0x55, 0x69,
-	0x6e, 0x74, 0x33, 0x32, 0x4d, 0x61, 0x70, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03,
-	0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14,
-	0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x76,
-	0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x3d, 0x0a, 0x0f, 0x41, 0x55, 0x69, 0x6e,
-	0x74, 0x36, 0x34, 0x4d, 0x61, 0x70, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b,
-	0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a,
-	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x76, 0x61,
-	0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x3d, 0x0a, 0x0f, 0x41, 0x6e, 0x49, 0x6e, 0x74,
-	0x33, 0x32, 0x4d, 0x61, 0x70, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65,
-	0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05,
-	0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x76, 0x61, 0x6c,
-	0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x3d, 0x0a, 0x0f, 0x41, 0x6e, 0x49, 0x6e, 0x74, 0x36,
-	0x34, 0x4d, 0x61, 0x70, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76,
-	0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75,
-	0x65, 0x3a, 0x02, 0x38, 0x01, 0x42, 0x1f, 0x5a, 0x1d, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e,
-	0x63, 0x6f, 0x6d, 0x2f, 0x54, 0x68, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x2f, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x65, 0x76, 0x61, 0x6c, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x05, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x3d, 0x0a, 0x0f,
+	0x41, 0x6e, 0x49, 0x6e, 0x74, 0x33, 0x32, 0x4d, 0x61, 0x70, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12,
+	0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x6b, 0x65,
+	0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x3d, 0x0a, 0x0f, 0x41,
+	0x6e, 0x49, 0x6e, 0x74, 0x36, 0x34, 0x4d, 0x61, 0x70, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10,
+	0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x6b, 0x65, 0x79,
+	0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x68, 0x0a, 0x10, 0x41, 0x4d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x4d, 0x61, 0x70, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10,
+	0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79,
+	0x12, 0x3e, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x28, 0x2e, 0x63, 0x6f, 0x6d, 0x2e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x74, 0x68, 0x65,
+	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x65, 0x76, 0x61, 0x6c, 0x2e,
+	0x53, 0x63, 0x6f, 0x70, 0x65, 0x54, 0x65, 0x73, 0x74, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x3a, 0x02, 0x38, 0x01, 0x42, 0x09, 0x0a, 0x07, 0x61, 0x5f, 0x6f, 0x6e, 0x65, 0x6f, 0x66, 0x42,
+	0x1f, 0x5a, 0x1d, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x54, 0x68,
+	0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x65, 0x76, 0x61, 0x6c,
+	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -212,7 +291,7 @@ func file_test_proto_rawDescGZIP() []byte {
 	return file_test_proto_rawDescData
 }
 
-var file_test_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_test_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
 var file_test_proto_goTypes = []interface{}{
 	(*ScopeTest)(nil), // 0: com.github.thecount.protoeval.ScopeTest
 	nil,               // 1: com.github.thecount.protoeval.ScopeTest.AStringMapEntry
@@ -221,6 +300,7 @@ var file_test_proto_goTypes = []interface{}{
 	nil,               // 4: com.github.thecount.protoeval.ScopeTest.AUint64MapEntry
 	nil,               // 5: com.github.thecount.protoeval.ScopeTest.AnInt32MapEntry
 	nil,               // 6: com.github.thecount.protoeval.ScopeTest.AnInt64MapEntry
+	nil,               // 7: com.github.thecount.protoeval.ScopeTest.AMessageMapEntry
 }
 var file_test_proto_depIdxs = []int32{
 	1, // 0: com.github.thecount.protoeval.ScopeTest.a_string_map:type_name -> com.github.thecount.protoeval.ScopeTest.AStringMapEntry
@@ -229,11 +309,14 @@ var file_test_proto_depIdxs = []int32{
 	4, // 3: com.github.thecount.protoeval.ScopeTest.a_uint64_map:type_name -> com.github.thecount.protoeval.ScopeTest.AUint64MapEntry
 	5, // 4: com.github.thecount.protoeval.ScopeTest.an_int32_map:type_name -> com.github.thecount.protoeval.ScopeTest.AnInt32MapEntry
 	6, // 5: com.github.thecount.protoeval.ScopeTest.an_int64_map:type_name -> com.github.thecount.protoeval.ScopeTest.AnInt64MapEntry
-	6, // [6:6] is the sub-list for method output_type
-	6, // [6:6] is the sub-list for method input_type
-	6, // [6:6] is the sub-list for extension type_name
-	6, // [6:6] is the sub-list for extension extendee
-	0, // [0:6] is the sub-list for field type_name
+	0, // 6: com.github.thecount.protoeval.ScopeTest.a_message_list:type_name -> com.github.thecount.protoeval.ScopeTest
+	7, // 7: com.github.thecount.protoeval.ScopeTest.a_message_map:type_name -> com.github.thecount.protoeval.ScopeTest.AMessageMapEntry
+	0, // 8: com.github.thecount.protoeval.ScopeTest.AMessageMapEntry.value:type_name -> com.github.thecount.protoeval.ScopeTest
+	9, // [9:9] is the sub-list for method output_type
+	9, // [9:9] is the sub-list for method input_type
+	9, // [9:9] is the sub-list for extension type_name
+	9, // [9:9] is the sub-list for extension extendee
+	0, // [0:9] is the sub-list for field type_name
 }
 
 func init() { file_test_proto_init() }
@@ -255,13 +338,17 @@ func file_test_proto_init() {
 			}
 		}
 	}
+	file_test_proto_msgTypes[0].OneofWrappers = []interface{}{
+		(*ScopeTest_AnInt)(nil),
+		(*ScopeTest_AString)(nil),
+	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_test_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   7,
+			NumMessages:   8,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -0,0 +1,128 @@
+package protoeval
+
+import (
+	"fmt"
+
+	"github.com/google/cel-go/common/types"
+	"github.com/google/cel-go/common/types/ref"
+	"github.com/google/cel-go/common/types/traits"
+)
+
+// binaryOperator is a binary CEL operator.
+type binaryOperator func(lhs, rhs ref.Val) ref.Val
+
+// evalBinaryOperator evaluates the given operands and applies op to them.
+// name is the name of the operator for error messages.
+func evalBinaryOperator(
+	env *Env, cyclesLeft *int, name string, operands *Value_ValueList,
+	op binaryOperator,
+) (ref.Val, error) {
+	if len(operands.GetValues()) != 2 {
+		return nil, fmt.Errorf("%s requires exactly two operands, got %d",
+			name, len(operands.GetValues()))
+	}
+	var vals [2]ref.Val
+	for i, value := range operands.Values {
+		rv, err := eval(env, cyclesLeft, value)
+		if err != nil {
+			return rv, fmt.Errorf("eval %s operand %d: %w", name, i, err)
+		}
+		if types.IsError(rv) {
+			return rv, nil
+		}
+		vals[i] = rv
+	}
+	return op(vals[0], vals[1]), nil
+}
+
+// celEqual implements the CEL == operator.
+func celEqual(lhs, rhs ref.Val) ref.Val {
+	return lhs.Equal(rhs)
+}
+
+// celNotEqual implements the CEL != operator.
+func celNotEqual(lhs, rhs ref.Val) ref.Val {
+	rv := lhs.Equal(rhs)
+	if bv, ok := rv.(types.Bool); ok {
+		return !bv
+	}
+	return rv
+}
+
+// celComparison returns a binaryOperator comparing its operands with
+// traits.Comparer. The operator yields true if pred is true for the comparison
+// result.
+func celComparison(pred func(cmp types.Int) bool) binaryOperator {
+	return func(lhs, rhs ref.Val) ref.Val {
+		comparer, ok := lhs.(traits.Comparer)
+		if !ok {
+			return types.NoSuchOverloadErr()
+		}
+		rv := comparer.Compare(rhs)
+		if cmp, ok := rv.(types.Int); ok {
+			return types.Bool(pred(cmp))
+		}
+		return rv
+	}
+}
+
+// Comparison operators.
+var (
+	celLess         = celComparison(func(cmp types.Int) bool { return cmp < 0 })
+	celLessEqual    = celComparison(func(cmp types.Int) bool { return cmp <= 0 })
+	celGreater      = celComparison(func(cmp types.Int) bool { return cmp > 0 })
+	celGreaterEqual = celComparison(func(cmp types.Int) bool { return cmp >= 0 })
+)
+
+// celAdd implements the CEL + operator.
+func celAdd(lhs, rhs ref.Val) ref.Val {
+	if adder, ok := lhs.(traits.Adder); ok {
+		return adder.Add(rhs)
+	}
+	return types.NoSuchOverloadErr()
+}
+
+// celSubtract implements the CEL binary - operator.
+func celSubtract(lhs, rhs ref.Val) ref.Val {
+	if subtractor, ok := lhs.(traits.Subtractor); ok {
+		return subtractor.Subtract(rhs)
+	}
+	return types.NoSuchOverloadErr()
+}
+
+// celMultiply implements the CEL * operator.
+func celMultiply(lhs, rhs ref.Val) ref.Val {
+	if multiplier, ok := lhs.(traits.Multiplier); ok {
+		return multiplier.Multiply(rhs)
+	}
+	return types.NoSuchOverloadErr()
+}
+
+// celDivide implements the CEL / operator.
+func celDivide(lhs, rhs ref.Val) ref.Val {
+	if divider, ok := lhs.(traits.Divider); ok {
+		return divider.Divide(rhs)
+	}
+	return types.NoSuchOverloadErr()
+}
+
+// celModulo implements the CEL % operator.
+func celModulo(lhs, rhs ref.Val) ref.Val {
+	if modder, ok := lhs.(traits.Modder); ok {
+		return modder.Modulo(rhs)
+	}
+	return types.NoSuchOverloadErr()
+}
+
+// celNegate implements the CEL unary - operator.
+func celNegate(val ref.Val) ref.Val {
+	// types.Bool implements traits.Negater for logical negation, which CEL
+	// expresses with a different operator.
+	if val.Type() == types.BoolType {
+		return types.NoSuchOverloadErr()
+	}
+	if negater, ok := val.(traits.Negater); ok {
+		return negater.Negate()
+	}
+	return types.NoSuchOverloadErr()
+}
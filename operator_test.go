@@ -0,0 +1,97 @@
+package protoeval
+
+import (
+	"testing"
+	"time"
+)
+
+// TestOperators tests the comparison and arithmetic operators.
+func TestOperators(t *testing.T) {
+	testmsg := &ScopeTest{
+		AScalar: 42,
+	}
+	env := NewEnv()
+	for _, tc := range []struct {
+		value    string
+		expected interface{}
+	}{
+		{`{ "eq": { "values": [ { "scope": ["a_scalar"] }, { "int": 42 } ] } }`,
+			true},
+		{`{ "eq": { "values": [ { "basic_value": "a" }, { "basic_value": "b" } ] } }`,
+			false},
+		{`{ "ne": { "values": [ { "int": 1 }, { "int": 2 } ] } }`, true},
+		{`{ "lt": { "values": [ { "int": 1 }, { "int": 2 } ] } }`, true},
+		{`{ "le": { "values": [ { "uint": 2 }, { "uint": 2 } ] } }`, true},
+		{`{ "gt": { "values": [ { "basic_value": 1 }, { "basic_value": 2 } ] } }`,
+			false},
+		{`{ "ge": { "values": [ { "basic_value": "b" }, { "basic_value": "a" } ] } }`,
+			true},
+		{`{ "add": { "values": [ { "scope": ["a_scalar"] }, { "int": 8 } ] } }`,
+			int64(50)},
+		{`{ "add": { "values": [ { "basic_value": "a" }, { "basic_value": "b" } ] } }`,
+			"ab"},
+		{`{ "sub": { "values": [ { "uint": 8 }, { "uint": 3 } ] } }`, uint64(5)},
+		{`{ "mul": { "values": [ { "basic_value": 1.5 }, { "basic_value": 2 } ] } }`,
+			3.0},
+		{`{ "div": { "values": [ { "int": 7 }, { "int": 2 } ] } }`, int64(3)},
+		{`{ "mod": { "values": [ { "int": 7 }, { "int": 2 } ] } }`, int64(1)},
+		{`{ "neg": { "scope": ["a_scalar"] } }`, int64(-42)},
+	} {
+		result, err := evalJSON(env, testmsg, tc.value)
+		if err != nil {
+			t.Errorf("eval %s: %s", tc.value, err)
+			continue
+		}
+		if result != tc.expected {
+			t.Errorf("eval %s: expected %v, got %v", tc.value, tc.expected, result)
+		}
+	}
+}
+
+// TestOperatorsTime tests timestamp and duration arithmetic.
+func TestOperatorsTime(t *testing.T) {
+	env := NewEnv()
+	result, err := evalJSON(env, &ScopeTest{}, `
+    { "add": { "values": [
+      { "timestamp": "2021-01-01T00:00:00Z" },
+      { "duration": "3600s" }
+    ] } }
+  `)
+	if err != nil {
+		t.Fatalf("eval add: %s", err)
+	}
+	expected := time.Date(2021, 1, 1, 1, 0, 0, 0, time.UTC)
+	if ts, ok := result.(time.Time); !ok || !ts.Equal(expected) {
+		t.Errorf("expected %v, got %v", expected, result)
+	}
+	result, err = evalJSON(env, &ScopeTest{}, `
+    { "neg": { "sub": { "values": [
+      { "timestamp": "2021-01-01T00:00:00Z" },
+      { "timestamp": "2021-01-01T00:01:00Z" }
+    ] } } }
+  `)
+	if err != nil {
+		t.Fatalf("eval sub: %s", err)
+	}
+	if result != time.Minute {
+		t.Errorf("expected 60s, got %v", result)
+	}
+}
+
+// TestOperatorErrors tests operator errors.
+func TestOperatorErrors(t *testing.T) {
+	env := NewEnv()
+	for _, value := range []string{
+		`{ "eq": { "values": [ { "int": 1 } ] } }`,
+		`{ "add": { "values": [ { "int": 1 }, { "uint": 1 } ] } }`,
+		`{ "lt": { "values": [ { "int": 1 }, { "basic_value": "a" } ] } }`,
+		`{ "div": { "values": [ { "int": 1 }, { "int": 0 } ] } }`,
+		`{ "mod": { "values": [ { "basic_value": 1 }, { "basic_value": 2 } ] } }`,
+		`{ "neg": { "basic_value": true } }`,
+		`{ "neg": { "uint": 1 } }`,
+	} {
+		if _, err := evalJSON(env, &ScopeTest{}, value); err == nil {
+			t.Errorf("expected error with %s", value)
+		}
+	}
+}
@@ -0,0 +1,339 @@
+package protoeval
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/google/cel-go/common/types"
+	"github.com/google/cel-go/common/types/ref"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// pathTarget describes the target of a patch path. Exactly one of msg, list
+// or mp is set. fd is the descriptor of the target field, or of the list or
+// map field the target element belongs to.
+type pathTarget struct {
+	// msg is the message containing the target field.
+	msg protoreflect.Message
+
+	// list is the list containing the target element.
+	list protoreflect.List
+
+	// index is the index of the target element in list.
+	index int
+
+	// mp is the map containing the target entry.
+	mp protoreflect.Map
+
+	// key is the key of the target entry in mp.
+	key protoreflect.MapKey
+
+	// fd is the field descriptor.
+	fd protoreflect.FieldDescriptor
+}
+
+// stepField returns the descriptor of the field of a message with the given
+// descriptor selected by the given path step.
+func stepField(
+	step *structpb.Value, desc protoreflect.MessageDescriptor,
+) (protoreflect.FieldDescriptor, error) {
+	switch x := step.Kind.(type) {
+	case *structpb.Value_StringValue:
+		return findField(desc, x.StringValue)
+	case *structpb.Value_NumberValue:
+		fn := protoreflect.FieldNumber(x.NumberValue)
+		test := float64(fn)
+		if test != x.NumberValue {
+			return nil, fmt.Errorf("invalid field number: %f", x.NumberValue)
+		}
+		return findFieldByNumber(desc, fn)
+	default:
+		return nil, fmt.Errorf("cannot select message field with %T", step.Kind)
+	}
+}
+
+// checkPath checks that the given path is valid for messages with the given
+// descriptor, without regard to any actual message contents.
+func checkPath(
+	desc protoreflect.MessageDescriptor, path []*structpb.Value,
+) error {
+	if len(path) == 0 {
+		return errors.New("empty path")
+	}
+	var container protoreflect.FieldDescriptor
+	for i, step := range path {
+		switch step.Kind.(type) {
+		case *structpb.Value_NullValue, *structpb.Value_ListValue:
+			return fmt.Errorf("path step %d: wildcard or multi-select", i)
+		}
+		switch {
+		case container != nil && container.IsList():
+			if _, err := stepListIndex(step); err != nil {
+				return fmt.Errorf("path step %d: %w", i, err)
+			}
+			desc, container = container.Message(), nil
+		case container != nil:
+			if _, err := stepMapKey(step, container.MapKey()); err != nil {
+				return fmt.Errorf("path step %d: %w", i, err)
+			}
+			desc, container = container.MapValue().Message(), nil
+		case desc == nil:
+			return fmt.Errorf("path step %d: cannot select into scalar value", i)
+		default:
+			fd, err := stepField(step, desc)
+			if err != nil {
+				return fmt.Errorf("path step %d: %w", i, err)
+			}
+			if fd.IsList() || fd.IsMap() {
+				desc, container = nil, fd
+			} else {
+				desc = fd.Message()
+			}
+		}
+	}
+	return nil
+}
+
+// resolvePath resolves the given patch path relative to msg. If create is
+// true, unset message fields and missing map entries along the path are
+// created as needed. Otherwise, resolvePath returns nil without error if the
+// path leads through such a field or entry.
+func resolvePath(
+	msg protoreflect.Message, path []*structpb.Value, create bool,
+) (*pathTarget, error) {
+	if len(path) == 0 {
+		return nil, errors.New("empty path")
+	}
+	var (
+		list protoreflect.List
+		mp   protoreflect.Map
+		fd   protoreflect.FieldDescriptor
+	)
+	for i, step := range path {
+		switch step.Kind.(type) {
+		case *structpb.Value_NullValue, *structpb.Value_ListValue:
+			return nil, fmt.Errorf("path step %d: wildcard or multi-select", i)
+		}
+		last := i == len(path)-1
+		switch {
+		case list != nil:
+			idx, err := stepListIndex(step)
+			if err != nil {
+				return nil, fmt.Errorf("path step %d: %w", i, err)
+			}
+			if idx < 0 || idx >= list.Len() {
+				return nil, fmt.Errorf("path step %d: list index %d out of bounds",
+					i, idx)
+			}
+			if last {
+				return &pathTarget{list: list, index: idx, fd: fd}, nil
+			}
+			if fd.Message() == nil {
+				return nil, fmt.Errorf("path step %d: cannot select into %s",
+					i+1, fd.Kind())
+			}
+			msg, list = list.Get(idx).Message(), nil
+		case mp != nil:
+			key, err := stepMapKey(step, fd.MapKey())
+			if err != nil {
+				return nil, fmt.Errorf("path step %d: %w", i, err)
+			}
+			if last {
+				return &pathTarget{mp: mp, key: key, fd: fd}, nil
+			}
+			if fd.MapValue().Message() == nil {
+				return nil, fmt.Errorf("path step %d: cannot select into %s",
+					i+1, fd.MapValue().Kind())
+			}
+			if !create && !mp.Has(key) {
+				return nil, nil
+			}
+			msg, mp = mp.Mutable(key).Message(), nil
+		default:
+			var err error
+			fd, err = stepField(step, msg.Descriptor())
+			if err != nil {
+				return nil, fmt.Errorf("path step %d: %w", i, err)
+			}
+			if last {
+				return &pathTarget{msg: msg, fd: fd}, nil
+			}
+			if fd.Message() == nil && !fd.IsList() {
+				return nil, fmt.Errorf("path step %d: cannot select into %s",
+					i+1, fd.Kind())
+			}
+			if !create && !fd.IsList() && !msg.Has(fd) {
+				return nil, nil
+			}
+			switch {
+			case fd.IsList():
+				list = msg.Mutable(fd).List()
+			case fd.IsMap():
+				mp = msg.Mutable(fd).Map()
+			default:
+				msg = msg.Mutable(fd).Message()
+			}
+		}
+	}
+	panic("BUG: path loop did not return")
+}
+
+// set sets this target to the given value.
+func (t *pathTarget) set(val ref.Val) error {
+	switch {
+	case t.msg != nil:
+		return val2protofield(val, t.msg, t.fd)
+	case t.list != nil:
+		if val.Type() == types.NullType {
+			return errors.New("cannot set list element to null")
+		}
+		elt, err := val2protoelem(val, t.fd, t.list.NewElement())
+		if err != nil {
+			return err
+		}
+		t.list.Set(t.index, elt)
+		return nil
+	default:
+		if val.Type() == types.NullType {
+			t.mp.Clear(t.key)
+			return nil
+		}
+		value, err := val2protoelem(val, t.fd, t.mp.NewValue())
+		if err != nil {
+			return err
+		}
+		t.mp.Set(t.key, value)
+		return nil
+	}
+}
+
+// clear clears this target.
+func (t *pathTarget) clear() {
+	switch {
+	case t.msg != nil:
+		t.msg.Clear(t.fd)
+	case t.list != nil:
+		n := t.list.Len()
+		for i := t.index; i < n-1; i++ {
+			t.list.Set(i, t.list.Get(i+1))
+		}
+		t.list.Truncate(n - 1)
+	default:
+		t.mp.Clear(t.key)
+	}
+}
+
+// append appends the given value to this target, which must be a list field.
+func (t *pathTarget) append(val ref.Val) error {
+	if t.msg == nil || !t.fd.IsList() {
+		return errors.New("append target is not a list field")
+	}
+	list := t.msg.Mutable(t.fd).List()
+	elt, err := val2protoelem(val, t.fd, list.NewElement())
+	if err != nil {
+		return err
+	}
+	list.Append(elt)
+	return nil
+}
+
+// patch evaluates the given value kind. The scope value of env must be a
+// message.
+func patch(env *Env, cyclesLeft *int, p *Value_Patch) (ref.Val, error) {
+	if env.scope.multi != nil {
+		return nil, errors.New("cannot patch wildcard or multi-select scope")
+	}
+	msg, ok := env.scope.value.Interface().(protoreflect.Message)
+	if !ok {
+		return nil, errors.New("patch scope is not a message")
+	}
+	msg, err := unwrapAny(msg)
+	if err != nil {
+		return nil, err
+	}
+	result := proto.Clone(msg.Interface()).ProtoReflect()
+	for i, op := range p.Operations {
+		rv, err := patchOperation(env, cyclesLeft, result, op)
+		if err != nil {
+			return rv, fmt.Errorf("patch operation %d: %w", i, err)
+		}
+		if rv != nil {
+			return rv, nil
+		}
+	}
+	return celTypeRegistry.NativeToValue(result.Interface()), nil
+}
+
+// patchOperation applies the given patch operation to msg. If evaluation
+// yields a CEL error value, patchOperation returns it.
+func patchOperation(
+	env *Env, cyclesLeft *int, msg protoreflect.Message,
+	op *Value_Patch_Operation,
+) (ref.Val, error) {
+	if op.When != nil {
+		rv, err := eval(env, cyclesLeft, op.When)
+		if err != nil {
+			return rv, fmt.Errorf("eval when: %w", err)
+		}
+		if types.IsError(rv) {
+			return rv, nil
+		}
+		bv, ok := rv.(types.Bool)
+		if !ok {
+			return nil, fmt.Errorf("eval when: expected bool, got %T", rv)
+		}
+		if !bv {
+			return nil, nil
+		}
+	}
+	path := op.Path.GetValues()
+	switch x := op.Op.(type) {
+	case nil:
+		return nil, errors.New("operation missing")
+	case *Value_Patch_Operation_Set:
+		rv, err := eval(env, cyclesLeft, x.Set)
+		if err != nil {
+			return rv, fmt.Errorf("eval set: %w", err)
+		}
+		if types.IsError(rv) {
+			return rv, nil
+		}
+		target, err := resolvePath(msg, path, true)
+		if err != nil {
+			return nil, err
+		}
+		if err = target.set(rv); err != nil {
+			return nil, fmt.Errorf("set: %w", err)
+		}
+		return nil, nil
+	case *Value_Patch_Operation_Clear:
+		target, err := resolvePath(msg, path, false)
+		if err != nil {
+			return nil, err
+		}
+		if target != nil {
+			target.clear()
+		}
+		return nil, nil
+	case *Value_Patch_Operation_Append:
+		rv, err := eval(env, cyclesLeft, x.Append)
+		if err != nil {
+			return rv, fmt.Errorf("eval append: %w", err)
+		}
+		if types.IsError(rv) {
+			return rv, nil
+		}
+		target, err := resolvePath(msg, path, true)
+		if err != nil {
+			return nil, err
+		}
+		if err = target.append(rv); err != nil {
+			return nil, fmt.Errorf("append: %w", err)
+		}
+		return nil, nil
+	default:
+		panic(fmt.Sprintf("BUG: unsupported patch operation %T", op.Op))
+	}
+}
@@ -0,0 +1,171 @@
+package protoeval
+
+import (
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// TestPatch tests patching a copy of the scope message.
+func TestPatch(t *testing.T) {
+	testmsg := &ScopeTest{
+		AScalar:    42,
+		AList:      []int32{1, 2, 3},
+		AStringMap: map[string]int32{"x": 1, "y": 2},
+		AMessageList: []*ScopeTest{
+			{AScalar: 1},
+		},
+	}
+	original := proto.Clone(testmsg)
+	env := NewEnv()
+	result, err := evalJSON(env, testmsg, `
+    { "patch": { "operations": [
+      { "path": ["a_scalar"], "set": { "int": 7 } },
+      { "path": ["a_list", 1], "clear": {} },
+      { "path": ["a_list"], "append": { "int": 9 } },
+      { "path": ["a_string_map", "x"], "set": { "int": 5 } },
+      { "path": ["a_string_map", "y"], "clear": {} },
+      { "path": ["a_message_list", 0, "a_scalar"], "set": { "int": 3 } },
+      { "path": ["a_message_map", "new", "a_list"],
+        "set": { "basic_value": [4, 5] } },
+      { "path": ["a_message_map", "missing", "a_scalar"], "clear": {} },
+      { "path": [9], "append": { "basic_value": { "a_scalar": 6 } } }
+    ] } }
+  `)
+	if err != nil {
+		t.Fatalf("eval: %s", err)
+	}
+	expected := &ScopeTest{
+		AScalar:    7,
+		AList:      []int32{1, 3, 9},
+		AStringMap: map[string]int32{"x": 5},
+		AMessageList: []*ScopeTest{
+			{AScalar: 3},
+			{AScalar: 6},
+		},
+		AMessageMap: map[string]*ScopeTest{
+			"new": {AList: []int32{4, 5}},
+		},
+	}
+	msg, ok := result.(proto.Message)
+	if !ok {
+		t.Fatalf("result type %T is not proto.Message", result)
+	}
+	if !proto.Equal(expected, msg) {
+		t.Errorf("expected %v, got %v", expected, msg)
+	}
+	if !proto.Equal(original, testmsg) {
+		t.Errorf("original message modified: %v", testmsg)
+	}
+}
+
+// TestPatchWhen tests conditional patch operations.
+func TestPatchWhen(t *testing.T) {
+	env := NewEnv()
+	const value = `
+    { "patch": { "operations": [
+      { "path": ["a_scalar"], "set": { "int": 1 },
+        "when": { "program": { "code": "scope.value.a_scalar == 0" } } },
+      { "path": ["a_list"], "clear": {},
+        "when": { "program": { "code": "size(scope.value.a_list) > 2" } } }
+    ] } }
+  `
+	result, err := evalJSON(env, &ScopeTest{
+		AList: []int32{1, 2, 3},
+	}, value)
+	if err != nil {
+		t.Fatalf("eval: %s", err)
+	}
+	expected := &ScopeTest{AScalar: 1}
+	if !proto.Equal(expected, result.(proto.Message)) {
+		t.Errorf("expected %v, got %v", expected, result)
+	}
+	result, err = evalJSON(env, &ScopeTest{
+		AScalar: 2,
+		AList:   []int32{1},
+	}, value)
+	if err != nil {
+		t.Fatalf("eval: %s", err)
+	}
+	expected = &ScopeTest{AScalar: 2, AList: []int32{1}}
+	if !proto.Equal(expected, result.(proto.Message)) {
+		t.Errorf("expected %v, got %v", expected, result)
+	}
+}
+
+// TestPatchErrors tests invalid patches.
+func TestPatchErrors(t *testing.T) {
+	testmsg := &ScopeTest{
+		AList: []int32{1},
+	}
+	env := NewEnv()
+	for _, value := range []string{
+		`{ "patch": { "operations": [ { "path": [], "clear": {} } ] } }`,
+		`{ "patch": { "operations": [ { "path": ["a_scalar"] } ] } }`,
+		`{ "patch": { "operations": [ { "path": ["nope"], "clear": {} } ] } }`,
+		`{ "patch": { "operations": [ { "path": [null], "clear": {} } ] } }`,
+		`{ "patch": { "operations": [ { "path": ["a_list", 1], "clear": {} } ] } }`,
+		`{ "patch": { "operations": [ { "path": ["a_scalar", 0], "clear": {} } ] } }`,
+		`{ "patch": { "operations": [
+      { "path": ["a_message_list", 0], "clear": {} } ] } }`,
+		`{ "patch": { "operations": [
+      { "path": ["a_message_list", 0, "a_scalar"], "clear": {} } ] } }`,
+		`{ "patch": { "operations": [
+      { "path": ["a_scalar"], "append": { "int": 1 } } ] } }`,
+		`{ "scope": ["a_list"],
+      "patch": { "operations": [ { "path": [0], "clear": {} } ] } }`,
+	} {
+		if _, err := evalJSON(env, testmsg, value); err == nil {
+			t.Errorf("expected error with %s", value)
+		}
+	}
+}
+
+// TestMessageClear tests clearing parts of a constructed message.
+func TestMessageClear(t *testing.T) {
+	testmsg := &ScopeTest{
+		AScalar:    42,
+		AList:      []int32{1, 2, 3},
+		AStringMap: map[string]int32{"x": 1, "y": 2},
+	}
+	env := NewEnv()
+	result, err := evalJSON(env, testmsg, `
+    { "message": {
+      "type": "com.github.thecount.protoeval.ScopeTest",
+      "fields": {
+        "a_scalar": { "scope": ["a_scalar"] },
+        "a_list": { "scope": ["a_list"] },
+        "a_string_map": { "scope": ["a_string_map"] }
+      },
+      "clear": [
+        ["a_scalar"],
+        ["a_list", 0],
+        ["a_string_map", "y"],
+        ["a_message_map", "missing", "a_scalar"]
+      ]
+    } }
+  `)
+	if err != nil {
+		t.Fatalf("eval: %s", err)
+	}
+	expected := &ScopeTest{
+		AList:      []int32{2, 3},
+		AStringMap: map[string]int32{"x": 1},
+	}
+	if !proto.Equal(expected, result.(proto.Message)) {
+		t.Errorf("expected %v, got %v", expected, result)
+	}
+	for _, path := range []string{
+		`["nope"]`, `["a_scalar", 0]`, `["a_list", "x"]`, `["a_bool_map", 1]`,
+		`["a_message_list", 0, "nope"]`, `["a_list", 0]`, `[]`,
+	} {
+		if _, err := evalJSON(env, testmsg, `
+      { "message": {
+        "type": "com.github.thecount.protoeval.ScopeTest",
+        "clear": [`+path+`]
+      } }
+    `); err == nil {
+			t.Errorf("expected error with clear path %s", path)
+		}
+	}
+}
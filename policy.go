@@ -0,0 +1,165 @@
+package protoeval
+
+//go:generate protoc --go_out=. protoeval/policy.proto
+
+import (
+	"errors"
+	"fmt"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// Decision is the outcome of an authorization with a PolicySet.
+type Decision struct {
+	// Effect is the decision, either Policy_ALLOW or Policy_DENY.
+	Effect Policy_Effect
+
+	// Matched lists the applicable policies which made the decision, in policy
+	// set order. If no policy applies, Matched is empty and the decision is
+	// the default effect of the policy set.
+	Matched []PolicyMatch
+}
+
+// Allowed reports whether this decision allows the request.
+func (d *Decision) Allowed() bool {
+	return d.Effect == Policy_ALLOW
+}
+
+// PolicyMatch describes an applicable policy.
+type PolicyMatch struct {
+	// ID is the ID of the policy.
+	ID string
+
+	// Effect is the effect of the policy.
+	Effect Policy_Effect
+
+	// Reason explains the decision of the policy. It is the result of the
+	// reason Value of the policy, or its description if the policy has no
+	// reason Value.
+	Reason string
+}
+
+// checkPolicySet checks that the given policy set is well-formed.
+func checkPolicySet(policySet *PolicySet) error {
+	switch policySet.Algorithm {
+	case PolicySet_DENY_OVERRIDES, PolicySet_FIRST_APPLICABLE:
+	default:
+		return fmt.Errorf("unsupported combining algorithm %s",
+			policySet.Algorithm)
+	}
+	switch policySet.DefaultEffect {
+	case Policy_EFFECT_UNSPECIFIED, Policy_ALLOW, Policy_DENY:
+	default:
+		return fmt.Errorf("unsupported default effect %s",
+			policySet.DefaultEffect)
+	}
+	ids := make(map[string]bool, len(policySet.Policies))
+	for i, policy := range policySet.Policies {
+		if policy.Id == "" {
+			return fmt.Errorf("policy %d has no ID", i)
+		}
+		if ids[policy.Id] {
+			return fmt.Errorf("duplicate policy ID %s", policy.Id)
+		}
+		ids[policy.Id] = true
+		if policy.Effect != Policy_ALLOW && policy.Effect != Policy_DENY {
+			return fmt.Errorf("policy %s: unsupported effect %s", policy.Id,
+				policy.Effect)
+		}
+	}
+	return nil
+}
+
+// evalPolicy evaluates the given policy for request and principal within a
+// clone of env. If the policy applies, a match is returned. Otherwise, nil
+// is returned.
+func evalPolicy(
+	env *Env, policy *Policy, request proto.Message, principal interface{},
+) (*PolicyMatch, error) {
+	if policy.Condition != nil {
+		result, err := Eval(env.Clone(), request, policy.Condition, principal)
+		if err != nil {
+			return nil, fmt.Errorf("condition: %w", err)
+		}
+		applies, ok := result.(bool)
+		if !ok {
+			return nil, fmt.Errorf("condition yielded %T instead of bool", result)
+		}
+		if !applies {
+			return nil, nil
+		}
+	}
+	match := &PolicyMatch{
+		ID:     policy.Id,
+		Effect: policy.Effect,
+		Reason: policy.Description,
+	}
+	if policy.Reason != nil {
+		result, err := Eval(env.Clone(), request, policy.Reason, principal)
+		if err != nil {
+			return nil, fmt.Errorf("reason: %w", err)
+		}
+		reason, ok := result.(string)
+		if !ok {
+			return nil, fmt.Errorf("reason yielded %T instead of string", result)
+		}
+		match.Reason = reason
+	}
+	return match, nil
+}
+
+// Authorize decides whether the given policy set allows the given request by
+// the given principal. Each policy is evaluated within a clone of env, with
+// request as initial scope and principal as argument 0, so policies can
+// refer to the principal as args[0] in CEL programs. The principal may be
+// any value Eval accepts as argument, including a protobuf message, or nil.
+//
+// The policies are combined according to the algorithm of the policy set. An
+// error in any policy evaluated aborts the authorization with an error, so
+// callers must treat errors as denials.
+func Authorize(
+	env *Env, policySet *PolicySet, request proto.Message,
+	principal interface{},
+) (*Decision, error) {
+	if env == nil {
+		return nil, errors.New("env is nil")
+	}
+	if policySet == nil {
+		return nil, errors.New("policy set is nil")
+	}
+	if err := checkPolicySet(policySet); err != nil {
+		return nil, fmt.Errorf("policy set %s: %w", policySet.Name, err)
+	}
+	var allows, denies []PolicyMatch
+	for _, policy := range policySet.Policies {
+		match, err := evalPolicy(env, policy, request, principal)
+		if err != nil {
+			return nil, fmt.Errorf("policy set %s: policy %s: %w",
+				policySet.Name, policy.Id, err)
+		}
+		if match == nil {
+			continue
+		}
+		if policySet.Algorithm == PolicySet_FIRST_APPLICABLE {
+			return &Decision{
+				Effect:  match.Effect,
+				Matched: []PolicyMatch{*match},
+			}, nil
+		}
+		if match.Effect == Policy_DENY {
+			denies = append(denies, *match)
+		} else {
+			allows = append(allows, *match)
+		}
+	}
+	switch {
+	case len(denies) != 0:
+		return &Decision{Effect: Policy_DENY, Matched: denies}, nil
+	case len(allows) != 0:
+		return &Decision{Effect: Policy_ALLOW, Matched: allows}, nil
+	case policySet.DefaultEffect == Policy_ALLOW:
+		return &Decision{Effect: Policy_ALLOW}, nil
+	default:
+		return &Decision{Effect: Policy_DENY}, nil
+	}
+}
@@ -0,0 +1,171 @@
+package protoeval
+
+import (
+	"reflect"
+	"testing"
+
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// policyTestSet is a policy set for ScopeTest requests, with a principal
+// map as argument.
+const policyTestSet = `{
+  "name": "test",
+  "policies": [
+    { "id": "admin",
+      "effect": "ALLOW",
+      "condition": { "program": { "code": "'admin' in args[0].roles" } },
+      "description": "admins may do anything" },
+    { "id": "small",
+      "effect": "ALLOW",
+      "condition": { "program": { "code": "scope.value.a_scalar < 10" } },
+      "description": "small requests are fine" },
+    { "id": "blocked",
+      "effect": "DENY",
+      "condition": { "program": { "code": "args[0].name == 'mallory'" } },
+      "reason": { "program": { "code": "args[0].name + ' is blocked'" } } }
+  ]
+}`
+
+// TestAuthorize tests authorization with the combining algorithms.
+func TestAuthorize(t *testing.T) {
+	var policySet PolicySet
+	if err := protojson.Unmarshal([]byte(policyTestSet), &policySet); err != nil {
+		t.Fatalf("unmarshal policy set: %s", err)
+	}
+	principal := func(name string, roles ...interface{}) interface{} {
+		return map[string]interface{}{
+			"name":  name,
+			"roles": append([]interface{}{}, roles...),
+		}
+	}
+	for _, test := range []struct {
+		name      string
+		algorithm PolicySet_Algorithm
+		scalar    int32
+		principal interface{}
+		expected  *Decision
+	}{
+		{
+			name:      "deny overrides allow",
+			scalar:    1,
+			principal: principal("mallory", "admin"),
+			expected: &Decision{
+				Effect: Policy_DENY,
+				Matched: []PolicyMatch{
+					{"blocked", Policy_DENY, "mallory is blocked"},
+				},
+			},
+		},
+		{
+			name:      "all allows",
+			scalar:    1,
+			principal: principal("alice", "admin"),
+			expected: &Decision{
+				Effect: Policy_ALLOW,
+				Matched: []PolicyMatch{
+					{"admin", Policy_ALLOW, "admins may do anything"},
+					{"small", Policy_ALLOW, "small requests are fine"},
+				},
+			},
+		},
+		{
+			name:      "default",
+			scalar:    10,
+			principal: principal("bob"),
+			expected:  &Decision{Effect: Policy_DENY},
+		},
+		{
+			name:      "first applicable",
+			algorithm: PolicySet_FIRST_APPLICABLE,
+			scalar:    1,
+			principal: principal("mallory", "admin"),
+			expected: &Decision{
+				Effect: Policy_ALLOW,
+				Matched: []PolicyMatch{
+					{"admin", Policy_ALLOW, "admins may do anything"},
+				},
+			},
+		},
+		{
+			name:      "first applicable deny",
+			algorithm: PolicySet_FIRST_APPLICABLE,
+			scalar:    10,
+			principal: principal("mallory"),
+			expected: &Decision{
+				Effect: Policy_DENY,
+				Matched: []PolicyMatch{
+					{"blocked", Policy_DENY, "mallory is blocked"},
+				},
+			},
+		},
+	} {
+		policySet.Algorithm = test.algorithm
+		decision, err := Authorize(NewEnv(), &policySet,
+			&ScopeTest{AScalar: test.scalar}, test.principal)
+		if err != nil {
+			t.Errorf("%s: %s", test.name, err)
+			continue
+		}
+		if !reflect.DeepEqual(decision, test.expected) {
+			t.Errorf("%s: expected %+v, got %+v", test.name, test.expected,
+				decision)
+		}
+		if decision.Allowed() != (test.expected.Effect == Policy_ALLOW) {
+			t.Errorf("%s: Allowed() inconsistent with effect", test.name)
+		}
+	}
+	policySet.Algorithm = PolicySet_DENY_OVERRIDES
+	policySet.DefaultEffect = Policy_ALLOW
+	decision, err := Authorize(NewEnv(), &policySet, &ScopeTest{AScalar: 10},
+		principal("bob"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !decision.Allowed() || len(decision.Matched) != 0 {
+		t.Errorf("expected default allow, got %+v", decision)
+	}
+}
+
+// TestAuthorizeErrors tests malformed policy sets and policies.
+func TestAuthorizeErrors(t *testing.T) {
+	program := func(code string) *Value {
+		return &Value{
+			Value: &Value_Program_{Program: &Value_Program{Code: code}},
+		}
+	}
+	for name, policySet := range map[string]*PolicySet{
+		"no id": {
+			Policies: []*Policy{{Effect: Policy_ALLOW}},
+		},
+		"duplicate id": {
+			Policies: []*Policy{
+				{Id: "a", Effect: Policy_ALLOW},
+				{Id: "a", Effect: Policy_DENY},
+			},
+		},
+		"no effect": {
+			Policies: []*Policy{{Id: "a"}},
+		},
+		"non-bool condition": {
+			Policies: []*Policy{
+				{Id: "a", Effect: Policy_ALLOW, Condition: program("1")},
+			},
+		},
+		"non-string reason": {
+			Policies: []*Policy{
+				{Id: "a", Effect: Policy_ALLOW, Reason: program("1")},
+			},
+		},
+		"condition error": {
+			Policies: []*Policy{
+				{Id: "a", Effect: Policy_ALLOW, Condition: program("nope")},
+			},
+		},
+	} {
+		if _, err := Authorize(NewEnv(), policySet, &ScopeTest{},
+			nil); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
@@ -2,6 +2,8 @@ package protoeval
 
 import (
 	"testing"
+
+	"github.com/google/cel-go/common/types"
 )
 
 // TestProgram tests running a simple CEL program.
@@ -70,3 +72,85 @@ func TestProgramStore(t *testing.T) {
 		t.Errorf("expected 42, got %d", scalar)
 	}
 }
+
+// TestProgramWildcardScope tests accessing a list-shaped scope from a CEL
+// program.
+func TestProgramWildcardScope(t *testing.T) {
+	testmsg := &ScopeTest{
+		AMessageList: []*ScopeTest{
+			{AScalar: 1},
+			{AScalar: 2},
+		},
+	}
+	env := NewEnv()
+	result, err := evalJSON(env, testmsg, `
+    {
+      "scope": ["a_message_list", null, "a_scalar"],
+      "program": { "code": "scope.list[0] + scope.list[1]" }
+    }
+  `)
+	if err != nil {
+		t.Fatalf("eval program: %s", err)
+	}
+	if result != int64(3) {
+		t.Errorf("expected 3, got %v", result)
+	}
+}
+
+// TestProgramTypedScope tests native access to scope values and the parent
+// scope chain from a CEL program.
+func TestProgramTypedScope(t *testing.T) {
+	testmsg := &ScopeTest{
+		AScalar:    40,
+		ABoolMap:   map[bool]int32{true: 1},
+		AnInt32Map: map[int32]int32{-7: 1},
+	}
+	env := NewEnv()
+	result, err := evalJSON(env, testmsg, `
+    {
+      "scope": ["a_bool_map"],
+      "program": { "lines": [
+        "scope.map[true] + scope.parent.value.an_int32_map[-7] +",
+        "scope.parent.value.a_scalar +",
+        "(has(scope.parent.parent) ? 1 : 0) +",
+        "(scope.field_descriptor.name == 'a_bool_map' ? 0 : 100)"
+      ]}
+    }
+  `)
+	if err != nil {
+		t.Fatalf("eval program: %s", err)
+	}
+	if result != int64(42) {
+		t.Errorf("expected 42, got %v", result)
+	}
+}
+
+// TestProgramLazyScope tests that the CEL scope is only materialized when
+// a program accesses it.
+func TestProgramLazyScope(t *testing.T) {
+	env := NewEnv()
+	env.scope.Init((&ScopeTest{AScalar: 42}).ProtoReflect())
+	activation := newCelActivation(env)
+	for _, name := range []string{"env", "args"} {
+		if _, ok := activation.ResolveName(name); !ok {
+			t.Fatalf("%s not resolved", name)
+		}
+	}
+	if activation.scope != nil {
+		t.Fatal("scope materialized without access")
+	}
+	rv, ok := activation.ResolveName("scope")
+	if !ok {
+		t.Fatal("scope not resolved")
+	}
+	cs := rv.(*celScope)
+	if cs.value != nil {
+		t.Error("scope value materialized without access")
+	}
+	if cs.Get(types.String("value")).Value().(*ScopeTest).AScalar != 42 {
+		t.Error("wrong scope value")
+	}
+	if rv, _ = activation.ResolveName("scope"); rv != cs {
+		t.Error("scope not cached")
+	}
+}
@@ -0,0 +1,323 @@
+// Package protoevaltest runs test suites for protoeval Values.
+//
+// A test suite file contains a Suite message, encoded as JSON if the file
+// extension is .json, and in text format otherwise. Each test case evaluates
+// an input message according to a Value, and compares the outcome against
+// the expected result or error. Input messages are specified as Any
+// messages, so their types must be registered with the global protobuf type
+// registry.
+//
+// In update mode, the expected outcomes are replaced with the actual outcomes
+// instead, and the suite file is rewritten. This is useful to create golden
+// results for new test cases, which should then be reviewed.
+package protoevaltest
+
+//go:generate protoc --proto_path=.. --go_out=.. ../protoevaltest/suite.proto
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/TheCount/protoeval"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// CaseResult is the result of running a test case.
+type CaseResult struct {
+	// Name is the name of the test case.
+	Name string
+
+	// Err describes why the test case failed. If the test case passed, Err is
+	// nil.
+	Err error
+}
+
+// isJSON reports whether the file at the given path is JSON encoded.
+func isJSON(path string) bool {
+	return strings.EqualFold(filepath.Ext(path), ".json")
+}
+
+// unmarshalFile decodes the file at the given path into msg.
+func unmarshalFile(path string, msg proto.Message) error {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	if isJSON(path) {
+		err = protojson.Unmarshal(data, msg)
+	} else {
+		err = prototext.Unmarshal(data, msg)
+	}
+	if err != nil {
+		return fmt.Errorf("decode %s: %w", path, err)
+	}
+	return nil
+}
+
+// LoadSuite loads the test suite from the file at the given path. If the
+// suite refers to a Value file, the Value is loaded into the value field of
+// the returned suite.
+func LoadSuite(path string) (*Suite, error) {
+	suite := &Suite{}
+	if err := unmarshalFile(path, suite); err != nil {
+		return nil, err
+	}
+	if suite.ValueFile != "" {
+		if suite.Value != nil {
+			return nil, fmt.Errorf("suite %s: both value and value_file set", path)
+		}
+		valuePath := suite.ValueFile
+		if !filepath.IsAbs(valuePath) {
+			valuePath = filepath.Join(filepath.Dir(path), valuePath)
+		}
+		suite.Value = &protoeval.Value{}
+		if err := unmarshalFile(valuePath, suite.Value); err != nil {
+			return nil, fmt.Errorf("suite %s: %w", path, err)
+		}
+	}
+	return suite, nil
+}
+
+// WriteSuite writes the given test suite to the file at the given path. If
+// the suite refers to a Value file, the Value itself is not written.
+func WriteSuite(path string, suite *Suite) error {
+	if suite.ValueFile != "" {
+		suite = proto.Clone(suite).(*Suite)
+		suite.Value = nil
+	}
+	var data []byte
+	var err error
+	if isJSON(path) {
+		data, err = protojson.MarshalOptions{Multiline: true}.Marshal(suite)
+	} else {
+		data, err = prototext.MarshalOptions{Multiline: true}.Marshal(suite)
+	}
+	if err != nil {
+		return fmt.Errorf("encode suite: %w", err)
+	}
+	return ioutil.WriteFile(path, data, 0644)
+}
+
+// evalCase evaluates the given test case of the given suite, and returns
+// the result as JSON value. If coverage is not nil, it collects the coverage
+// of the evaluation.
+func evalCase(
+	suite *Suite, c *Case, coverage *protoeval.Coverage,
+) (*structpb.Value, error) {
+	value := c.Value
+	if value == nil {
+		value = suite.Value
+	}
+	if value == nil {
+		return nil, errors.New("value missing")
+	}
+	if c.Input == nil {
+		return nil, errors.New("input missing")
+	}
+	msg, err := c.Input.UnmarshalNew()
+	if err != nil {
+		return nil, fmt.Errorf("decode input: %w", err)
+	}
+	env := protoeval.NewEnv().SetCoverage(coverage)
+	for _, envMap := range []map[string]*structpb.Value{suite.Env, c.Env} {
+		for key, v := range envMap {
+			if err = env.Set(key, v.AsInterface()); err != nil {
+				return nil, fmt.Errorf("set env %s: %w", key, err)
+			}
+		}
+	}
+	args := make([]interface{}, len(c.Args))
+	for i, arg := range c.Args {
+		args[i] = arg.AsInterface()
+	}
+	result, err := protoeval.EvalToProto(env, msg, value,
+		(&structpb.Value{}).ProtoReflect().Descriptor(), args...)
+	if err != nil {
+		return nil, evalError{err}
+	}
+	return result.(*structpb.Value), nil
+}
+
+// evalError wraps an evaluation error, to distinguish it from errors in the
+// test case setup.
+type evalError struct {
+	error
+}
+
+// Unwrap returns the wrapped error.
+func (e evalError) Unwrap() error {
+	return e.error
+}
+
+// formatValue formats the given JSON value for display.
+func formatValue(v *structpb.Value) string {
+	data, err := json.Marshal(v.AsInterface())
+	if err != nil {
+		return fmt.Sprintf("<%s>", err)
+	}
+	return string(data)
+}
+
+// RunCase runs the given test case of the given suite. It returns nil if the
+// test case passed, and an error describing the failure otherwise.
+func RunCase(suite *Suite, c *Case) error {
+	return runCase(suite, c, nil)
+}
+
+// runCase runs the given test case of the given suite, collecting coverage
+// if coverage is not nil.
+func runCase(suite *Suite, c *Case, coverage *protoeval.Coverage) error {
+	result, err := evalCase(suite, c, coverage)
+	var evalErr evalError
+	if err != nil && !errors.As(err, &evalErr) {
+		return err
+	}
+	switch x := c.Expect.(type) {
+	case nil:
+		return errors.New("expected outcome missing")
+	case *Case_Result:
+		if err != nil {
+			return fmt.Errorf("unexpected error: %w", evalErr.error)
+		}
+		if !proto.Equal(x.Result, result) {
+			return fmt.Errorf("result mismatch:\n  expected: %s\n  actual:   %s",
+				formatValue(x.Result), formatValue(result))
+		}
+		return nil
+	case *Case_Error:
+		if err == nil {
+			return fmt.Errorf("expected error, got result %s", formatValue(result))
+		}
+		if !strings.Contains(err.Error(), x.Error.Contains) {
+			return fmt.Errorf("error mismatch:\n  expected substring: %s\n  actual: %s",
+				x.Error.Contains, err)
+		}
+		if x.Error.FailCode != "" {
+			var failErr *protoeval.FailError
+			if !errors.As(err, &failErr) {
+				return fmt.Errorf("expected fail code %s, got error: %w",
+					x.Error.FailCode, err)
+			}
+			if failErr.Code != x.Error.FailCode {
+				return fmt.Errorf("fail code mismatch:\n  expected: %s\n  actual:   %s",
+					x.Error.FailCode, failErr.Code)
+			}
+		}
+		return nil
+	default:
+		panic(fmt.Sprintf("BUG: unsupported expectation type %T", c.Expect))
+	}
+}
+
+// checkNames checks that the test cases in the given suite have unique,
+// non-empty names.
+func checkNames(suite *Suite) error {
+	names := make(map[string]bool, len(suite.Cases))
+	for i, c := range suite.Cases {
+		if c.Name == "" {
+			return fmt.Errorf("test case %d has no name", i)
+		}
+		if names[c.Name] {
+			return fmt.Errorf("duplicate test case name %s", c.Name)
+		}
+		names[c.Name] = true
+	}
+	return nil
+}
+
+// RunSuite runs all test cases in the given suite.
+func RunSuite(suite *Suite) ([]CaseResult, error) {
+	return RunSuiteCoverage(suite, nil)
+}
+
+// RunSuiteCoverage runs all test cases in the given suite like RunSuite, and
+// collects the coverage of the evaluations in coverage. A coverage report
+// for the Value of the suite shows which parts of the Value the test cases
+// do not exercise.
+func RunSuiteCoverage(
+	suite *Suite, coverage *protoeval.Coverage,
+) ([]CaseResult, error) {
+	if err := checkNames(suite); err != nil {
+		return nil, err
+	}
+	results := make([]CaseResult, len(suite.Cases))
+	for i, c := range suite.Cases {
+		results[i] = CaseResult{
+			Name: c.Name,
+			Err:  runCase(suite, c, coverage),
+		}
+	}
+	return results, nil
+}
+
+// UpdateSuite runs all test cases in the given suite, and replaces their
+// expected outcomes with the actual outcomes. Errors in the test case setup
+// (e. g., a missing input) are not considered outcomes, and are returned
+// instead.
+func UpdateSuite(suite *Suite) error {
+	if err := checkNames(suite); err != nil {
+		return err
+	}
+	for _, c := range suite.Cases {
+		result, err := evalCase(suite, c, nil)
+		var evalErr evalError
+		switch {
+		case err == nil:
+			c.Expect = &Case_Result{Result: result}
+		case errors.As(err, &evalErr):
+			expected := &Error{
+				Contains: evalErr.Error(),
+			}
+			var failErr *protoeval.FailError
+			if errors.As(err, &failErr) {
+				expected.FailCode = failErr.Code
+			}
+			c.Expect = &Case_Error{Error: expected}
+		default:
+			return fmt.Errorf("test case %s: %w", c.Name, err)
+		}
+	}
+	return nil
+}
+
+// Test runs the test suites in the files matching the given glob patterns as
+// subtests of t, one subtest per suite file and test case.
+func Test(t *testing.T, patterns ...string) {
+	t.Helper()
+	for _, pattern := range patterns {
+		paths, err := filepath.Glob(pattern)
+		if err != nil {
+			t.Fatalf("glob %s: %s", pattern, err)
+		}
+		if len(paths) == 0 {
+			t.Errorf("no test suite files match %s", pattern)
+		}
+		for _, path := range paths {
+			t.Run(filepath.Base(path), func(t *testing.T) {
+				suite, err := LoadSuite(path)
+				if err != nil {
+					t.Fatalf("load suite: %s", err)
+				}
+				if err = checkNames(suite); err != nil {
+					t.Fatal(err)
+				}
+				for _, c := range suite.Cases {
+					c := c
+					t.Run(c.Name, func(t *testing.T) {
+						if err := RunCase(suite, c); err != nil {
+							t.Error(err)
+						}
+					})
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,162 @@
+package protoevaltest
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/TheCount/protoeval"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// TestSuites runs the test suites in testdata.
+func TestSuites(t *testing.T) {
+	Test(t, "testdata/*.suite.*")
+}
+
+// newCase returns a test case with an Error message as input.
+func newCase(t *testing.T, name, code string) *Case {
+	t.Helper()
+	input, err := anypb.New(&Error{Contains: code})
+	if err != nil {
+		t.Fatalf("create input: %s", err)
+	}
+	return &Case{
+		Name:  name,
+		Input: input,
+	}
+}
+
+// newProgram returns a Value with the given CEL program.
+func newProgram(code string) *protoeval.Value {
+	return &protoeval.Value{
+		Value: &protoeval.Value_Program_{
+			Program: &protoeval.Value_Program{Code: code},
+		},
+	}
+}
+
+// TestRunFailures tests that failing test cases are reported.
+func TestRunFailures(t *testing.T) {
+	suite := &Suite{
+		Value: newProgram("scope.value.contains"),
+	}
+	wrongResult := newCase(t, "wrong_result", "a")
+	wrongResult.Expect = &Case_Result{Result: structpb.NewStringValue("b")}
+	unexpectedError := newCase(t, "unexpected_error", "a")
+	unexpectedError.Value = newProgram("nope")
+	unexpectedError.Expect = &Case_Result{Result: structpb.NewNullValue()}
+	missingError := newCase(t, "missing_error", "a")
+	missingError.Expect = &Case_Error{Error: &Error{}}
+	wrongFailCode := newCase(t, "wrong_fail_code", "a")
+	wrongFailCode.Value = &protoeval.Value{
+		Value: &protoeval.Value_Fail_{
+			Fail: &protoeval.Value_Fail{Code: "E1"},
+		},
+	}
+	wrongFailCode.Expect = &Case_Error{Error: &Error{FailCode: "E2"}}
+	noExpectation := newCase(t, "no_expectation", "a")
+	suite.Cases = []*Case{
+		wrongResult, unexpectedError, missingError, wrongFailCode, noExpectation,
+	}
+	results, err := RunSuite(suite)
+	if err != nil {
+		t.Fatalf("run suite: %s", err)
+	}
+	expected := []string{
+		"result mismatch", "unexpected error", "expected error",
+		"fail code mismatch", "expected outcome missing",
+	}
+	for i, result := range results {
+		if result.Err == nil {
+			t.Errorf("%s: expected failure", result.Name)
+		} else if !strings.Contains(result.Err.Error(), expected[i]) {
+			t.Errorf("%s: expected %q in error, got: %s", result.Name, expected[i],
+				result.Err)
+		}
+	}
+	suite.Cases = append(suite.Cases, newCase(t, "wrong_result", "b"))
+	if _, err = RunSuite(suite); err == nil {
+		t.Error("expected error for duplicate test case names")
+	}
+}
+
+// TestUpdate tests the update mode.
+func TestUpdate(t *testing.T) {
+	dir, err := ioutil.TempDir("", "protoevaltest")
+	if err != nil {
+		t.Fatalf("create temporary directory: %s", err)
+	}
+	defer os.RemoveAll(dir)
+	suite := &Suite{
+		Value: newProgram("scope.value.contains + '!'"),
+	}
+	result := newCase(t, "result", "a")
+	fail := newCase(t, "fail", "b")
+	fail.Value = &protoeval.Value{
+		Value: &protoeval.Value_Fail_{
+			Fail: &protoeval.Value_Fail{Code: "E1"},
+		},
+	}
+	suite.Cases = []*Case{result, fail}
+	if err = UpdateSuite(suite); err != nil {
+		t.Fatalf("update suite: %s", err)
+	}
+	for _, name := range []string{"suite.json", "suite.textproto"} {
+		path := filepath.Join(dir, name)
+		if err = WriteSuite(path, suite); err != nil {
+			t.Fatalf("write suite %s: %s", name, err)
+		}
+		loaded, err := LoadSuite(path)
+		if err != nil {
+			t.Fatalf("load suite %s: %s", name, err)
+		}
+		if !proto.Equal(suite, loaded) {
+			t.Errorf("%s: loaded suite differs from written suite", name)
+		}
+		results, err := RunSuite(loaded)
+		if err != nil {
+			t.Fatalf("run suite %s: %s", name, err)
+		}
+		for _, result := range results {
+			if result.Err != nil {
+				t.Errorf("%s/%s: %s", name, result.Name, result.Err)
+			}
+		}
+	}
+	if x := result.Expect.(*Case_Result).Result.GetStringValue(); x != "a!" {
+		t.Errorf("expected result a!, got %s", x)
+	}
+	if x := fail.Expect.(*Case_Error).Error.FailCode; x != "E1" {
+		t.Errorf("expected fail code E1, got %s", x)
+	}
+	noInput := &Case{Name: "no_input"}
+	suite.Cases = append(suite.Cases, noInput)
+	if err = UpdateSuite(suite); err == nil {
+		t.Error("expected error for test case without input")
+	}
+}
+
+// TestRunSuiteCoverage tests coverage collection for test suites.
+func TestRunSuiteCoverage(t *testing.T) {
+	suite, err := LoadSuite("testdata/fail.suite.textproto")
+	if err != nil {
+		t.Fatalf("load suite: %s", err)
+	}
+	coverage := protoeval.NewCoverage()
+	if _, err = RunSuiteCoverage(suite, coverage); err != nil {
+		t.Fatalf("run suite: %s", err)
+	}
+	for _, node := range coverage.Nodes(suite.Value) {
+		if node.Hits != 1 {
+			t.Errorf("%s: expected 1 hit, got %d", node.Path, node.Hits)
+		}
+	}
+	if hits := coverage.Hits(suite.Cases[1].Value); hits != 1 {
+		t.Errorf("expected 1 hit for case value, got %d", hits)
+	}
+}
@@ -84,3 +84,138 @@ func TestRangeScopeMap(t *testing.T) {
 		t.Errorf("expected sum=10, got %d", sum)
 	}
 }
+
+// TestRangeSortedKeys tests ranging over a map in sorted key order.
+func TestRangeSortedKeys(t *testing.T) {
+	testmsg := &ScopeTest{
+		AStringMap: map[string]int32{
+			"one":   1,
+			"two":   2,
+			"three": 3,
+			"four":  4,
+		},
+		AnInt64Map: map[int64]int32{
+			5:  1,
+			-3: 2,
+			0:  3,
+		},
+	}
+	env := NewEnv()
+	for i := 0; i < 10; i++ {
+		result, err := evalJSON(env, testmsg, `
+      {
+        "scope": ["a_string_map"],
+        "range": {
+          "value": { "program": { "code": "args[1] > 1 ? args[0] : null" } },
+          "sorted_keys": true
+        }
+      }
+    `)
+		if err != nil {
+			t.Fatalf("eval sorted string map range: %s", err)
+		}
+		if result != "four" {
+			t.Fatalf("expected four, got %v", result)
+		}
+	}
+	env.SetSortedMapIteration(true)
+	for i := 0; i < 10; i++ {
+		result, err := evalJSON(env, testmsg, `
+      {
+        "scope": ["an_int64_map"],
+        "range": { "value": { "arg": 0 } }
+      }
+    `)
+		if err != nil {
+			t.Fatalf("eval sorted int map range: %s", err)
+		}
+		if result != int64(-3) {
+			t.Fatalf("expected -3, got %v", result)
+		}
+		result, err = evalJSON(env, testmsg, `
+      {
+        "scope": ["a_string_map"],
+        "fold": {
+          "init": { "basic_value": "" },
+          "value": { "add": { "values": [ { "arg": 2 }, { "arg": 0 } ] } }
+        }
+      }
+    `)
+		if err != nil {
+			t.Fatalf("eval sorted map fold: %s", err)
+		}
+		if result != "fouronethreetwo" {
+			t.Fatalf("expected fouronethreetwo, got %v", result)
+		}
+	}
+}
+
+// TestRangeBreakContinue tests break and continue within a range.
+func TestRangeBreakContinue(t *testing.T) {
+	testmsg := &ScopeTest{
+		AList: []int32{1, 2, 3, 4},
+	}
+	env := NewEnv()
+	for _, tc := range []struct {
+		name     string
+		value    string
+		expected int64
+	}{
+		{"break", `
+      { "scope": ["a_list"], "range": { "value": { "seq": { "values": [
+        { "program": { "code": "(env.sum+args[1]).store('sum').nix()" } },
+        { "switch": { "cases": [ {
+          "case": { "eq": { "values": [ { "arg": 1 }, { "int": 2 } ] } },
+          "then": { "break": 1 }
+        } ] } }
+      ] } } } }
+    `, 3},
+		{"continue", `
+      { "scope": ["a_list"], "range": { "value": { "seq": { "values": [
+        { "switch": { "cases": [ {
+          "case": { "eq": { "values": [
+            { "mod": { "values": [ { "arg": 1 }, { "int": 2 } ] } },
+            { "int": 0 }
+          ] } },
+          "then": { "continue": 1 }
+        } ] } },
+        { "program": { "code": "(env.sum+args[1]).store('sum').nix()" } }
+      ] } } } }
+    `, 4},
+		{"nested", `
+      { "while": {
+        "case": { "basic_value": true },
+        "then": { "scope": ["a_list"], "range": { "value": { "seq": {
+          "values": [
+            { "program": { "code": "(env.sum+args[1]).store('sum').nix()" } },
+            { "break": 2 }
+          ]
+        } } } }
+      } }
+    `, 1},
+	} {
+		if err := env.Set("sum", int64(0)); err != nil {
+			t.Fatalf("set sum=0 in environment: %s", err)
+		}
+		if _, err := evalJSON(env, testmsg, tc.value); err != nil {
+			t.Errorf("eval %s: %s", tc.name, err)
+			continue
+		}
+		sum, _ := env.Get("sum")
+		if sum != tc.expected {
+			t.Errorf("%s: expected sum=%d, got %v", tc.name, tc.expected, sum)
+		}
+	}
+	result, err := evalJSON(env, testmsg, `
+    { "scope": ["a_list"], "range": { "value": { "seq": { "values": [
+      { "arg": 1 },
+      { "break": 1 }
+    ] } } } }
+  `)
+	if err != nil {
+		t.Fatalf("eval break value: %s", err)
+	}
+	if result != int64(1) {
+		t.Errorf("expected 1, got %v", result)
+	}
+}
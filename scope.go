@@ -4,12 +4,13 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"sort"
 	"strconv"
 
+	"github.com/google/cel-go/common/types"
 	"github.com/google/cel-go/common/types/pb"
 	"github.com/google/cel-go/common/types/ref"
 	"google.golang.org/protobuf/reflect/protoreflect"
-	"google.golang.org/protobuf/types/known/anypb"
 	"google.golang.org/protobuf/types/known/structpb"
 )
 
@@ -27,6 +28,12 @@ type scope struct {
 	// value is the protobuf value of this evaluation scope.
 	value protoreflect.Value
 
+	// multi holds the selected scopes if this scope was created by a scope
+	// selection path with wildcard or multi-select steps. If multi is non-nil,
+	// desc and value are unset, and the value of this scope is the list of the
+	// values of the scopes in multi.
+	multi []*scope
+
 	// parent points to the parent scope. If this is the root scope,
 	// parent is nil.
 	parent *scope
@@ -34,8 +41,10 @@ type scope struct {
 
 // Init initialises this scope as a root scope for the specified message.
 func (s *scope) Init(msg protoreflect.Message) {
+	s.args = nil
 	s.desc = nil
 	s.value = protoreflect.ValueOfMessage(msg)
+	s.multi = nil
 	s.parent = nil
 }
 
@@ -46,17 +55,139 @@ func (s *scope) Shift(path *structpb.ListValue) (scope, error) {
 		return scope{
 			desc:   s.desc,
 			value:  s.value,
+			multi:  s.multi,
 			parent: s,
 		}, nil
 	}
-	var err error
+	current, fanned := []*scope{s}, false
+	if s.multi != nil {
+		current, fanned = s.multi, true
+	}
 	for i, step := range path.Values {
-		s, err = s.shiftStep(step)
+		next := make([]*scope, 0, len(current))
+		for j, elt := range current {
+			selected, err := elt.fanOut(step)
+			if err != nil {
+				if fanned {
+					return scope{}, fmt.Errorf("shift path index %d, selection %d: %w",
+						i, j, err)
+				}
+				return scope{}, fmt.Errorf("shift path index %d: %w", i, err)
+			}
+			next = append(next, selected...)
+		}
+		switch step.Kind.(type) {
+		case *structpb.Value_NullValue, *structpb.Value_ListValue:
+			fanned = true
+		}
+		current = next
+	}
+	if !fanned {
+		return *current[0], nil
+	}
+	return scope{
+		multi:  current,
+		parent: s,
+	}, nil
+}
+
+// fanOut shifts this scope by one step, which may be a wildcard or
+// multi-select step. Ordinary steps yield exactly one scope.
+func (s *scope) fanOut(step *structpb.Value) ([]*scope, error) {
+	switch x := step.Kind.(type) {
+	case *structpb.Value_NullValue:
+		return s.shiftWildcard()
+	case *structpb.Value_ListValue:
+		result := make([]*scope, 0, len(x.ListValue.Values))
+		for i, sel := range x.ListValue.Values {
+			switch sel.Kind.(type) {
+			case *structpb.Value_NullValue, *structpb.Value_ListValue:
+				return nil, fmt.Errorf(
+					"multi-select index %d: nested wildcard or multi-select", i)
+			}
+			child, err := s.shiftStep(sel)
+			if err != nil {
+				return nil, fmt.Errorf("multi-select index %d: %w", i, err)
+			}
+			result = append(result, child)
+		}
+		return result, nil
+	default:
+		child, err := s.shiftStep(step)
 		if err != nil {
-			return scope{}, fmt.Errorf("shift path index %d: %w", i, err)
+			return nil, err
+		}
+		return []*scope{child}, nil
+	}
+}
+
+// shiftWildcard shifts this scope to all its populated message fields
+// (in declaration order), list elements (in order), or map entries (in key
+// order, see sortedMapKeys).
+func (s *scope) shiftWildcard() ([]*scope, error) {
+	switch y := s.value.Interface().(type) {
+	case protoreflect.Message:
+		y, err := unwrapAny(y)
+		if err != nil {
+			return nil, err
+		}
+		fields := y.Descriptor().Fields()
+		result := make([]*scope, 0, fields.Len())
+		for i := 0; i < fields.Len(); i++ {
+			fd := fields.Get(i)
+			if !y.Has(fd) {
+				continue
+			}
+			result = append(result, &scope{
+				desc:   fd,
+				value:  y.Get(fd),
+				parent: s,
+			})
+		}
+		return result, nil
+	case protoreflect.Map:
+		keys := sortedMapKeys(y)
+		result := make([]*scope, len(keys))
+		for i, key := range keys {
+			result[i] = &scope{
+				desc:   s.desc,
+				value:  y.Get(key),
+				parent: s,
+			}
 		}
+		return result, nil
+	case protoreflect.List:
+		result := make([]*scope, y.Len())
+		for i := range result {
+			result[i] = &scope{
+				desc:   s.desc,
+				value:  y.Get(i),
+				parent: s,
+			}
+		}
+		return result, nil
+	default:
+		return nil, fmt.Errorf("cannot select all of %s", s.desc.Kind())
 	}
-	return *s, nil
+}
+
+// sortedMapKeys returns the keys of the given map in ascending order, as
+// by mapKeyLess.
+func sortedMapKeys(m protoreflect.Map) []protoreflect.MapKey {
+	keys := make([]protoreflect.MapKey, 0, m.Len())
+	m.Range(func(key protoreflect.MapKey, _ protoreflect.Value) bool {
+		keys = append(keys, key)
+		return true
+	})
+	sort.Slice(keys, func(i, j int) bool {
+		return mapKeyLess(mapKey2val(keys[i]), mapKey2val(keys[j]))
+	})
+	return keys
+}
+
+// mapKey2val converts the given protobuf map key to a CEL value.
+func mapKey2val(key protoreflect.MapKey) ref.Val {
+	return types.DefaultTypeAdapter.NativeToValue(key.Interface())
 }
 
 // shiftStep shifts this scope by one step.
@@ -65,18 +196,13 @@ func (s *scope) shiftStep(step *structpb.Value) (*scope, error) {
 	case *structpb.Value_StringValue:
 		switch y := s.value.Interface().(type) {
 		case protoreflect.Message:
-			desc := y.Descriptor()
-			if desc.FullName() == anypbName {
-				msg, err := y.Interface().(*anypb.Any).UnmarshalNew()
-				if err != nil {
-					return nil, fmt.Errorf("unwrap Any: %w", err)
-				}
-				y = msg.ProtoReflect()
-				desc = y.Descriptor()
+			y, err := unwrapAny(y)
+			if err != nil {
+				return nil, err
 			}
-			fd := desc.Fields().ByName(protoreflect.Name(x.StringValue))
-			if fd == nil {
-				return nil, fmt.Errorf("no such message field: %s", x.StringValue)
+			fd, err := findField(y.Descriptor(), x.StringValue)
+			if err != nil {
+				return nil, err
 			}
 			if fd.HasPresence() && !y.Has(fd) {
 				return nil, fmt.Errorf("message field %s not set", x.StringValue)
@@ -87,50 +213,9 @@ func (s *scope) shiftStep(step *structpb.Value) (*scope, error) {
 				parent: s,
 			}, nil
 		case protoreflect.Map:
-			var key protoreflect.MapKey
-			switch s.desc.MapKey().Kind() {
-			case protoreflect.StringKind:
-				key = protoreflect.ValueOfString(x.StringValue).MapKey()
-			case protoreflect.Int32Kind, protoreflect.Sint32Kind,
-				protoreflect.Sfixed32Kind:
-				n, err := strconv.ParseInt(x.StringValue, 0, 32)
-				if err != nil {
-					return nil, fmt.Errorf("map key '%s' invalid for map key kind %s",
-						x.StringValue, s.desc.MapKey().Kind())
-				}
-				key = protoreflect.ValueOfInt32(int32(n)).MapKey()
-			case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
-				n, err := strconv.ParseUint(x.StringValue, 0, 32)
-				if err != nil {
-					return nil, fmt.Errorf("map key '%s' invalid for map key kind %s",
-						x.StringValue, s.desc.MapKey().Kind())
-				}
-				key = protoreflect.ValueOfUint32(uint32(n)).MapKey()
-			case protoreflect.Int64Kind, protoreflect.Sint64Kind,
-				protoreflect.Sfixed64Kind:
-				n, err := strconv.ParseInt(x.StringValue, 0, 64)
-				if err != nil {
-					return nil, fmt.Errorf("map key '%s' invalid for map key kind %s",
-						x.StringValue, s.desc.MapKey().Kind())
-				}
-				key = protoreflect.ValueOfInt64(n).MapKey()
-			case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
-				n, err := strconv.ParseUint(x.StringValue, 0, 64)
-				if err != nil {
-					return nil, fmt.Errorf("map key '%s' invalid for map key kind %s",
-						x.StringValue, s.desc.MapKey().Kind())
-				}
-				key = protoreflect.ValueOfUint64(n).MapKey()
-			case protoreflect.BoolKind:
-				b, err := strconv.ParseBool(x.StringValue)
-				if err != nil {
-					return nil, fmt.Errorf("map key '%s' invalid for map key kind %s",
-						x.StringValue, s.desc.MapKey().Kind())
-				}
-				key = protoreflect.ValueOfBool(b).MapKey()
-			default:
-				panic(fmt.Sprintf("BUG: unsupported map key kind %s",
-					s.desc.MapKey().Kind()))
+			key, err := stepMapKey(step, s.desc.MapKey())
+			if err != nil {
+				return nil, err
 			}
 			if !y.Has(key) {
 				return nil, fmt.Errorf("map has no key '%s'", x.StringValue)
@@ -153,18 +238,13 @@ func (s *scope) shiftStep(step *structpb.Value) (*scope, error) {
 			if test != x.NumberValue {
 				return nil, fmt.Errorf("invalid field number: %f", x.NumberValue)
 			}
-			desc := y.Descriptor()
-			if desc.FullName() == anypbName {
-				msg, err := y.Interface().(*anypb.Any).UnmarshalNew()
-				if err != nil {
-					return nil, fmt.Errorf("unwrap Any: %w", err)
-				}
-				y = msg.ProtoReflect()
-				desc = y.Descriptor()
+			y, err := unwrapAny(y)
+			if err != nil {
+				return nil, err
 			}
-			fd := desc.Fields().ByNumber(fn)
-			if fd == nil {
-				return nil, fmt.Errorf("no such message field number: %d", fn)
+			fd, err := findFieldByNumber(y.Descriptor(), fn)
+			if err != nil {
+				return nil, err
 			}
 			if fd.HasPresence() && !y.Has(fd) {
 				return nil, fmt.Errorf("message field %s (%d) not set", fd.Name(), fn)
@@ -175,45 +255,9 @@ func (s *scope) shiftStep(step *structpb.Value) (*scope, error) {
 				parent: s,
 			}, nil
 		case protoreflect.Map:
-			var key protoreflect.MapKey
-			switch s.desc.MapKey().Kind() {
-			case protoreflect.StringKind:
-				return nil, errors.New("cannot index string key map with number")
-			case protoreflect.Int32Kind, protoreflect.Sint32Kind,
-				protoreflect.Sfixed32Kind:
-				n := int32(x.NumberValue)
-				test := float64(n)
-				if test != x.NumberValue {
-					return nil, fmt.Errorf("cannot convert %f to int32", x.NumberValue)
-				}
-				key = protoreflect.ValueOfInt32(n).MapKey()
-			case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
-				n := uint32(x.NumberValue)
-				test := float64(n)
-				if test != x.NumberValue {
-					return nil, fmt.Errorf("cannot convert %f to uint32", x.NumberValue)
-				}
-				key = protoreflect.ValueOfUint32(n).MapKey()
-			case protoreflect.Int64Kind, protoreflect.Sint64Kind,
-				protoreflect.Sfixed64Kind:
-				n := int64(x.NumberValue)
-				test := float64(n)
-				if test != x.NumberValue {
-					return nil, fmt.Errorf("cannot convert %f to int64", x.NumberValue)
-				}
-				key = protoreflect.ValueOfInt64(n).MapKey()
-			case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
-				n := uint64(x.NumberValue)
-				test := float64(n)
-				if test != x.NumberValue {
-					return nil, fmt.Errorf("cannot convert %f to uint64", x.NumberValue)
-				}
-				key = protoreflect.ValueOfUint64(n).MapKey()
-			case protoreflect.BoolKind:
-				return nil, errors.New("cannot index bool key map with number")
-			default:
-				panic(fmt.Sprintf("BUG: unsupported map key kind %s",
-					s.desc.MapKey().Kind()))
+			key, err := stepMapKey(step, s.desc.MapKey())
+			if err != nil {
+				return nil, err
 			}
 			if !y.Has(key) {
 				return nil, fmt.Errorf("map has no key %d", key.Interface())
@@ -224,10 +268,9 @@ func (s *scope) shiftStep(step *structpb.Value) (*scope, error) {
 				parent: s,
 			}, nil
 		case protoreflect.List:
-			idx := int(x.NumberValue)
-			test := float64(idx)
-			if test != x.NumberValue {
-				return nil, fmt.Errorf("cannot convert %f to list index", x.NumberValue)
+			idx, err := stepListIndex(step)
+			if err != nil {
+				return nil, err
 			}
 			if idx < 0 || idx >= y.Len() {
 				return nil, fmt.Errorf("list index %d out of bounds", idx)
@@ -245,11 +288,10 @@ func (s *scope) shiftStep(step *structpb.Value) (*scope, error) {
 		case protoreflect.Message:
 			return nil, errors.New("cannot index message with bool")
 		case protoreflect.Map:
-			if s.desc.MapKey().Kind() != protoreflect.BoolKind {
-				return nil, fmt.Errorf("cannot index %s with bool",
-					s.desc.MapKey().Kind())
+			key, err := stepMapKey(step, s.desc.MapKey())
+			if err != nil {
+				return nil, err
 			}
-			key := protoreflect.ValueOfBool(x.BoolValue).MapKey()
 			if !y.Has(key) {
 				return nil, fmt.Errorf("map has no key '%t'", x.BoolValue)
 			}
@@ -268,8 +310,131 @@ func (s *scope) shiftStep(step *structpb.Value) (*scope, error) {
 	}
 }
 
+// stepMapKey converts the given scope selection step to a key for the map
+// with the key descriptor kd.
+func stepMapKey(
+	step *structpb.Value, kd protoreflect.FieldDescriptor,
+) (protoreflect.MapKey, error) {
+	var key protoreflect.MapKey
+	switch x := step.Kind.(type) {
+	case *structpb.Value_StringValue:
+		switch kd.Kind() {
+		case protoreflect.StringKind:
+			key = protoreflect.ValueOfString(x.StringValue).MapKey()
+		case protoreflect.Int32Kind, protoreflect.Sint32Kind,
+			protoreflect.Sfixed32Kind:
+			n, err := strconv.ParseInt(x.StringValue, 0, 32)
+			if err != nil {
+				return key, fmt.Errorf("map key '%s' invalid for map key kind %s",
+					x.StringValue, kd.Kind())
+			}
+			key = protoreflect.ValueOfInt32(int32(n)).MapKey()
+		case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+			n, err := strconv.ParseUint(x.StringValue, 0, 32)
+			if err != nil {
+				return key, fmt.Errorf("map key '%s' invalid for map key kind %s",
+					x.StringValue, kd.Kind())
+			}
+			key = protoreflect.ValueOfUint32(uint32(n)).MapKey()
+		case protoreflect.Int64Kind, protoreflect.Sint64Kind,
+			protoreflect.Sfixed64Kind:
+			n, err := strconv.ParseInt(x.StringValue, 0, 64)
+			if err != nil {
+				return key, fmt.Errorf("map key '%s' invalid for map key kind %s",
+					x.StringValue, kd.Kind())
+			}
+			key = protoreflect.ValueOfInt64(n).MapKey()
+		case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+			n, err := strconv.ParseUint(x.StringValue, 0, 64)
+			if err != nil {
+				return key, fmt.Errorf("map key '%s' invalid for map key kind %s",
+					x.StringValue, kd.Kind())
+			}
+			key = protoreflect.ValueOfUint64(n).MapKey()
+		case protoreflect.BoolKind:
+			b, err := strconv.ParseBool(x.StringValue)
+			if err != nil {
+				return key, fmt.Errorf("map key '%s' invalid for map key kind %s",
+					x.StringValue, kd.Kind())
+			}
+			key = protoreflect.ValueOfBool(b).MapKey()
+		default:
+			panic(fmt.Sprintf("BUG: unsupported map key kind %s", kd.Kind()))
+		}
+	case *structpb.Value_NumberValue:
+		switch kd.Kind() {
+		case protoreflect.StringKind:
+			return key, errors.New("cannot index string key map with number")
+		case protoreflect.Int32Kind, protoreflect.Sint32Kind,
+			protoreflect.Sfixed32Kind:
+			n := int32(x.NumberValue)
+			test := float64(n)
+			if test != x.NumberValue {
+				return key, fmt.Errorf("cannot convert %f to int32", x.NumberValue)
+			}
+			key = protoreflect.ValueOfInt32(n).MapKey()
+		case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+			n := uint32(x.NumberValue)
+			test := float64(n)
+			if test != x.NumberValue {
+				return key, fmt.Errorf("cannot convert %f to uint32", x.NumberValue)
+			}
+			key = protoreflect.ValueOfUint32(n).MapKey()
+		case protoreflect.Int64Kind, protoreflect.Sint64Kind,
+			protoreflect.Sfixed64Kind:
+			n := int64(x.NumberValue)
+			test := float64(n)
+			if test != x.NumberValue {
+				return key, fmt.Errorf("cannot convert %f to int64", x.NumberValue)
+			}
+			key = protoreflect.ValueOfInt64(n).MapKey()
+		case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+			n := uint64(x.NumberValue)
+			test := float64(n)
+			if test != x.NumberValue {
+				return key, fmt.Errorf("cannot convert %f to uint64", x.NumberValue)
+			}
+			key = protoreflect.ValueOfUint64(n).MapKey()
+		case protoreflect.BoolKind:
+			return key, errors.New("cannot index bool key map with number")
+		default:
+			panic(fmt.Sprintf("BUG: unsupported map key kind %s", kd.Kind()))
+		}
+	case *structpb.Value_BoolValue:
+		if kd.Kind() != protoreflect.BoolKind {
+			return key, fmt.Errorf("cannot index %s with bool", kd.Kind())
+		}
+		key = protoreflect.ValueOfBool(x.BoolValue).MapKey()
+	default:
+		return key, fmt.Errorf("cannot index map with %T", step.Kind)
+	}
+	return key, nil
+}
+
+// stepListIndex converts the given scope selection step to a list index.
+// The index is not checked against the list bounds.
+func stepListIndex(step *structpb.Value) (int, error) {
+	x, ok := step.Kind.(*structpb.Value_NumberValue)
+	if !ok {
+		return 0, fmt.Errorf("cannot index list with %T", step.Kind)
+	}
+	idx := int(x.NumberValue)
+	test := float64(idx)
+	if test != x.NumberValue {
+		return 0, fmt.Errorf("cannot convert %f to list index", x.NumberValue)
+	}
+	return idx, nil
+}
+
 // Value returns the value of this scope.
 func (s *scope) Value() ref.Val {
+	if s.multi != nil {
+		values := make([]ref.Val, len(s.multi))
+		for i, elt := range s.multi {
+			values[i] = elt.Value()
+		}
+		return types.NewRefValList(celTypeRegistry, values)
+	}
 	switch x := s.value.Interface().(type) {
 	case protoreflect.Map:
 		// CEL cannot deal with protoreflect.Map directly, need to wrap it into
@@ -286,7 +451,12 @@ func (s *scope) Value() ref.Val {
 }
 
 // Value returns the default value of this scope.
+// The default value of a scope created by a wildcard or multi-select scope
+// selection path is the empty list.
 func (s *scope) DefaultValue() ref.Val {
+	if s.multi != nil {
+		return types.NewRefValList(celTypeRegistry, []ref.Val{})
+	}
 	switch x := s.value.Interface().(type) {
 	case protoreflect.Message: // s.parent and s.desc may be nil in this case
 		return celTypeRegistry.NativeToValue(x.Type().New())
@@ -344,6 +514,25 @@ func (s *scope) dropArgs(n int) error {
 	return nil
 }
 
+// snapshotArgs returns copies of the argument stacks of this scope and its
+// ancestors, innermost first, for use with restoreArgs.
+func (s *scope) snapshotArgs() [][]ref.Val {
+	var result [][]ref.Val
+	for ; s != nil; s = s.parent {
+		result = append(result, append([]ref.Val(nil), s.args...))
+	}
+	return result
+}
+
+// restoreArgs restores the argument stacks of this scope and its ancestors
+// from the given snapshot taken with snapshotArgs.
+func (s *scope) restoreArgs(snapshot [][]ref.Val) {
+	for _, args := range snapshot {
+		s.args = args
+		s = s.parent
+	}
+}
+
 // Arg returns the n-th argument.
 func (s *scope) Arg(n uint32) (ref.Val, error) {
 	if n > math.MaxInt32 {
@@ -1,9 +1,12 @@
 package protoeval
 
 import (
+	"reflect"
 	"testing"
 
+	"github.com/google/cel-go/common/types/ref"
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
 	"google.golang.org/protobuf/types/known/structpb"
 )
 
@@ -175,3 +178,102 @@ func TestBoolMapScope(t *testing.T) {
 		t.Errorf("expected 42, got %d", scalar)
 	}
 }
+
+// TestWildcardScope tests wildcard scope selection over lists and maps.
+func TestWildcardScope(t *testing.T) {
+	testmsg := &ScopeTest{
+		AMessageList: []*ScopeTest{
+			{AScalar: 1},
+			{AScalar: 2},
+			{AScalar: 3},
+		},
+		AStringMap: map[string]int32{
+			"c": 3,
+			"a": 1,
+			"b": 2,
+		},
+	}
+	env := NewEnv()
+	for _, path := range []string{
+		`["a_message_list", null, "a_scalar"]`,
+		`["a_string_map", null]`,
+		`["a_string_map", ["a", "b", "c"]]`,
+		`["a_message_list", [0, 1, 2], 1]`,
+	} {
+		result, err := evalJSON(env, testmsg, `{ "scope": `+path+` }`)
+		if err != nil {
+			t.Fatalf("eval %s: %s", path, err)
+		}
+		list, ok := result.([]ref.Val)
+		if !ok {
+			t.Fatalf("result type %T is not []ref.Val", result)
+		}
+		if len(list) != 3 {
+			t.Fatalf("expected 3 elements, got %d", len(list))
+		}
+		for i, elt := range list {
+			if elt.Value() != int64(i+1) {
+				t.Errorf("%s: expected %d at index %d, got %v",
+					path, i+1, i, elt.Value())
+			}
+		}
+	}
+	if _, err := evalJSON(env, testmsg, `
+    { "scope": ["a_string_map", ["a", "does_not_exist"]] }
+  `); err == nil {
+		t.Error("expected error selecting nonexistent map entry")
+	}
+}
+
+// TestWildcardScopeShift tests shifting a list-shaped scope further.
+func TestWildcardScopeShift(t *testing.T) {
+	testmsg := &ScopeTest{
+		AMessageList: []*ScopeTest{
+			{AList: []int32{1, 2}},
+			{AList: []int32{3, 4}},
+		},
+	}
+	env := NewEnv()
+	result, err := evalJSON(env, testmsg, `
+    {
+      "scope": ["a_message_list", null],
+      "seq": { "values": [{ "scope": ["a_list", 1] }] }
+    }
+  `)
+	if err != nil {
+		t.Fatalf("eval: %s", err)
+	}
+	list, ok := result.([]ref.Val)
+	if !ok {
+		t.Fatalf("result type %T is not []ref.Val", result)
+	}
+	if len(list) != 2 || list[0].Value() != int64(2) ||
+		list[1].Value() != int64(4) {
+		t.Errorf("expected [2, 4], got %v", list)
+	}
+}
+
+// TestSortedMapKeys tests sorting the keys of protobuf maps.
+func TestSortedMapKeys(t *testing.T) {
+	msg := (&ScopeTest{
+		ABoolMap:   map[bool]int32{true: 1, false: 2},
+		AUint32Map: map[uint32]int32{10: 1, 2: 2, 1 << 31: 3},
+		AnInt32Map: map[int32]int32{-1: 1, 3: 2, 0: 3},
+		AStringMap: map[string]int32{"b": 1, "aa": 2, "B": 3},
+	}).ProtoReflect()
+	for name, expected := range map[protoreflect.Name][]interface{}{
+		"a_bool_map":   {false, true},
+		"a_uint32_map": {uint32(2), uint32(10), uint32(1 << 31)},
+		"an_int32_map": {int32(-1), int32(0), int32(3)},
+		"a_string_map": {"B", "aa", "b"},
+	} {
+		fd := msg.Descriptor().Fields().ByName(name)
+		var keys []interface{}
+		for _, key := range sortedMapKeys(msg.Get(fd).Map()) {
+			keys = append(keys, key.Interface())
+		}
+		if !reflect.DeepEqual(keys, expected) {
+			t.Errorf("%s: expected %v, got %v", name, expected, keys)
+		}
+	}
+}
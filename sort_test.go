@@ -0,0 +1,109 @@
+package protoeval
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestSortBy tests sorting lists.
+func TestSortBy(t *testing.T) {
+	testmsg := &ScopeTest{
+		AMessageList: []*ScopeTest{
+			{AScalar: 1, AOneof: &ScopeTest_AnInt{AnInt: 0}, AList: []int32{1, 2}},
+			{AScalar: 2, AOneof: &ScopeTest_AnInt{AnInt: 1}},
+			{AScalar: 1, AOneof: &ScopeTest_AnInt{AnInt: 2}},
+			{AScalar: 1, AOneof: &ScopeTest_AnInt{AnInt: 3}, AList: []int32{1}},
+			{AScalar: 2, AOneof: &ScopeTest_AnInt{AnInt: 4}},
+		},
+	}
+	env := NewEnv()
+	var strs []string
+	if err := evalIntoJSON(env, testmsg, `
+    { "sort_by": { "iterable": { "basic_value": ["b", "c", "a"] } } }
+  `, &strs); err != nil {
+		t.Fatalf("eval sort: %s", err)
+	}
+	if !reflect.DeepEqual(strs, []string{"a", "b", "c"}) {
+		t.Errorf("expected [a b c], got %v", strs)
+	}
+	if err := evalIntoJSON(env, testmsg, `
+    { "sort_by": {
+      "iterable": { "basic_value": ["b", "c", "a"] },
+      "keys": [ { "descending": true } ]
+    } }
+  `, &strs); err != nil {
+		t.Fatalf("eval descending sort: %s", err)
+	}
+	if !reflect.DeepEqual(strs, []string{"c", "b", "a"}) {
+		t.Errorf("expected [c b a], got %v", strs)
+	}
+	var ids []int64
+	if err := evalIntoJSON(env, testmsg, `
+    { "transform": {
+      "iterable": { "scope": ["a_message_list"], "sort_by": { "keys": [
+        { "value": { "program": { "code": "args[1].a_scalar" } },
+          "descending": true },
+        { "value": { "program": { "code": "size(args[1].a_list)" } } }
+      ] } },
+      "value": { "program": { "code": "args[1].an_int" } }
+    } }
+  `, &ids); err != nil {
+		t.Fatalf("eval multi-key sort: %s", err)
+	}
+	if !reflect.DeepEqual(ids, []int64{1, 4, 2, 3, 0}) {
+		t.Errorf("expected [1 4 2 3 0], got %v", ids)
+	}
+}
+
+// TestGroupBy tests grouping aggregates.
+func TestGroupBy(t *testing.T) {
+	testmsg := &ScopeTest{
+		AList:      []int32{1, 2, 3, 4, 5},
+		AStringMap: map[string]int32{"one": 1, "two": 2},
+	}
+	env := NewEnv()
+	var groups map[int64][]int64
+	if err := evalIntoJSON(env, testmsg, `
+    { "scope": ["a_list"], "group_by": {
+      "key": { "mod": { "values": [ { "arg": 1 }, { "int": 2 } ] } }
+    } }
+  `, &groups); err != nil {
+		t.Fatalf("eval list group: %s", err)
+	}
+	expected := map[int64][]int64{0: {2, 4}, 1: {1, 3, 5}}
+	if !reflect.DeepEqual(groups, expected) {
+		t.Errorf("expected %v, got %v", expected, groups)
+	}
+	var names map[bool][]string
+	if err := evalIntoJSON(env, testmsg, `
+    { "scope": ["a_string_map"], "group_by": {
+      "key": { "gt": { "values": [ { "arg": 1 }, { "int": 1 } ] } },
+      "value": { "arg": 0 }
+    } }
+  `, &names); err != nil {
+		t.Fatalf("eval map group: %s", err)
+	}
+	expectedNames := map[bool][]string{false: {"one"}, true: {"two"}}
+	if !reflect.DeepEqual(names, expectedNames) {
+		t.Errorf("expected %v, got %v", expectedNames, names)
+	}
+}
+
+// TestSortGroupErrors tests sort_by and group_by errors.
+func TestSortGroupErrors(t *testing.T) {
+	testmsg := &ScopeTest{
+		AList:      []int32{1, 2},
+		AStringMap: map[string]int32{"one": 1},
+	}
+	env := NewEnv()
+	for _, value := range []string{
+		`{ "scope": ["a_string_map"], "sort_by": {} }`,
+		`{ "sort_by": { "iterable": { "basic_value": [1, "a"] } } }`,
+		`{ "scope": ["a_list"], "group_by": {} }`,
+		`{ "scope": ["a_list"], "group_by": { "key": { "basic_value": 1.5 } } }`,
+	} {
+		if _, err := evalJSON(env, testmsg, value); err == nil {
+			t.Errorf("expected error with %s", value)
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package protoeval
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/google/cel-go/common/types"
+	"github.com/google/cel-go/common/types/ref"
+)
+
+// Error kinds passed to try fallbacks.
+const (
+	tryKindFail = "fail"
+	tryKindCEL  = "cel"
+	tryKindEval = "eval"
+)
+
+// FailError is the error returned if an evaluation fails due to a fail Value
+// not handled by an enclosing try Value. The error may be wrapped.
+type FailError struct {
+	// Code is the user-defined error code.
+	Code string
+
+	// Message is the user-defined error message.
+	Message string
+}
+
+// Error implements error.Error.
+func (e *FailError) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("fail [%s]", e.Code)
+	}
+	return fmt.Sprintf("fail [%s]: %s", e.Code, e.Message)
+}
+
+// evalTry evaluates the try value kind.
+func evalTry(env *Env, cyclesLeft *int, try *Value_Try) (ref.Val, error) {
+	if try.Body == nil {
+		return nil, errors.New("try body missing")
+	}
+	args := env.scope.snapshotArgs()
+	rv, err := eval(env, cyclesLeft, try.Body)
+	var kind, code, msg string
+	var failErr *FailError
+	switch {
+	case err == nil:
+		if !types.IsError(rv) {
+			return rv, nil
+		}
+		kind, msg = tryKindCEL, rv.Value().(error).Error()
+	case errors.Is(err, ErrEvalTooLong), errors.Is(err, errBreak(0)),
+		errors.Is(err, errContinue(0)):
+		return rv, err
+	case errors.As(err, &failErr):
+		kind, code, msg = tryKindFail, failErr.Code, failErr.Message
+	default:
+		kind, msg = tryKindEval, err.Error()
+	}
+	// A failed evaluation may leave the argument stacks in disarray, including
+	// those of ancestor scopes if the body dropped more arguments than the
+	// current scope holds.
+	env.scope.restoreArgs(args)
+	if try.Fallback == nil {
+		return types.NullValue, nil
+	}
+	env.scope.PushArg(types.NewStringInterfaceMap(celTypeRegistry,
+		map[string]interface{}{
+			"message": msg,
+			"kind":    kind,
+			"code":    code,
+		}))
+	rv, err = eval(env, cyclesLeft, try.Fallback)
+	if err2 := env.scope.DropArgs(1); err2 != nil {
+		return nil, fmt.Errorf("try fallback drop error argument: %w", err2)
+	}
+	if err != nil {
+		return rv, fmt.Errorf("eval try fallback: %w", err)
+	}
+	return rv, nil
+}
+
+// evalFail evaluates the fail value kind.
+func evalFail(env *Env, cyclesLeft *int, fail *Value_Fail) (ref.Val, error) {
+	result := &FailError{
+		Code: fail.Code,
+	}
+	if fail.Message != nil {
+		rv, err := eval(env, cyclesLeft, fail.Message)
+		if err != nil {
+			return rv, fmt.Errorf("eval fail message: %w", err)
+		}
+		if types.IsError(rv) {
+			return rv, nil
+		}
+		msg, ok := rv.(types.String)
+		if !ok {
+			return nil, fmt.Errorf("eval fail message: expected string, got %T", rv)
+		}
+		result.Message = string(msg)
+	}
+	return nil, result
+}
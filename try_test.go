@@ -0,0 +1,95 @@
+package protoeval
+
+import (
+	"errors"
+	"testing"
+)
+
+// TestTry tests error handling with try.
+func TestTry(t *testing.T) {
+	testmsg := &ScopeTest{
+		AScalar: 42,
+	}
+	env := NewEnv()
+	const describe = `
+    "fallback": { "program": { "code":
+      "args[0].kind + ':' + args[0].code + ':' + args[0].message"
+    } }
+  `
+	for _, tc := range []struct {
+		value    string
+		expected interface{}
+	}{
+		{`{ "try": { "body": { "scope": ["a_scalar"] } } }`, int64(42)},
+		{`{ "try": { "body": { "scope": ["nope"] } } }`, nil},
+		{`{ "try": {
+      "body": { "fail": { "code": "E42", "message": { "basic_value": "bad" } } },
+      ` + describe + `
+    } }`, "fail:E42:bad"},
+		{`{ "try": {
+      "body": { "div": { "values": [ { "int": 1 }, { "int": 0 } ] } },
+      "fallback": { "program": { "code": "args[0].kind" } }
+    } }`, "cel"},
+		{`{ "try": {
+      "body": { "scope": ["nope"] },
+      "fallback": { "program": { "code": "args[0].kind" } }
+    } }`, "eval"},
+		{`{ "args": [ { "int": 1 } ], "try": {
+      "body": { "args": [ { "int": 2 } ], "fail": {} },
+      "fallback": { "arg": 1 }
+    } }`, int64(1)},
+		{`{ "args": [ { "int": 1 }, { "int": 2 } ], "add": { "values": [
+      { "scope": [], "try": {
+        "body": { "drop_args": 2, "fail": {} },
+        "fallback": { "arg": 2 }
+      } },
+      { "arg": 0 }
+    ] } }`, int64(3)},
+		{`{ "while": {
+      "case": { "basic_value": true },
+      "then": { "try": { "body": { "seq": { "values": [
+        { "int": 7 }, { "break": 1 }
+      ] } } } }
+    } }`, int64(7)},
+	} {
+		result, err := evalJSON(env, testmsg, tc.value)
+		if err != nil {
+			t.Errorf("eval %s: %s", tc.value, err)
+			continue
+		}
+		if result != tc.expected {
+			t.Errorf("eval %s: expected %v, got %v", tc.value, tc.expected, result)
+		}
+	}
+}
+
+// TestFail tests failing evaluations.
+func TestFail(t *testing.T) {
+	testmsg := &ScopeTest{}
+	env := NewEnv()
+	_, err := evalJSON(env, testmsg, `
+    { "fail": { "code": "E1", "message": { "basic_value": "failed" } } }
+  `)
+	var failErr *FailError
+	if !errors.As(err, &failErr) {
+		t.Fatalf("expected FailError, got %v", err)
+	}
+	if failErr.Code != "E1" || failErr.Message != "failed" {
+		t.Errorf("unexpected FailError %#v", failErr)
+	}
+	if _, err = evalJSON(env, testmsg, `
+    { "fail": { "message": { "int": 1 } } }
+  `); err == nil || errors.As(err, &failErr) {
+		t.Errorf("expected non-FailError error, got %v", err)
+	}
+	env.SetEvalMax(10)
+	_, err = evalJSON(env, testmsg, `
+    { "try": { "body": { "while": {
+      "case": { "basic_value": true },
+      "then": { "int": 1 }
+    } } } }
+  `)
+	if !errors.Is(err, ErrEvalTooLong) {
+		t.Errorf("expected ErrEvalTooLong, got %v", err)
+	}
+}
@@ -1,18 +1,67 @@
 package protoeval
 
 import (
+	"errors"
 	"fmt"
 	"reflect"
+	"strings"
 
+	"github.com/google/cel-go/common/types"
+	"github.com/google/cel-go/common/types/ref"
+	"github.com/google/cel-go/common/types/traits"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/reflect/protoreflect"
 	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/dynamicpb"
 	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
 )
 
 // anypbName is the full name of the well-known Any protobuf message.
 var anypbName = (&anypb.Any{}).ProtoReflect().Descriptor().FullName()
 
+// structpbName is the full name of the well-known Struct protobuf message.
+var structpbName = (&structpb.Struct{}).ProtoReflect().Descriptor().FullName()
+
+// structpbValueName is the full name of the well-known Value protobuf
+// message.
+var structpbValueName = (&structpb.Value{}).ProtoReflect().Descriptor().
+	FullName()
+
+// celWellKnownTypes is the set of full names of the well-known protobuf
+// message types CEL converts values to natively.
+var celWellKnownTypes = func() map[protoreflect.FullName]bool {
+	result := make(map[protoreflect.FullName]bool)
+	for _, msg := range []proto.Message{
+		&anypb.Any{}, &structpb.Value{}, &structpb.Struct{},
+		&structpb.ListValue{}, &durationpb.Duration{}, &timestamppb.Timestamp{},
+		&wrapperspb.BoolValue{}, &wrapperspb.BytesValue{},
+		&wrapperspb.DoubleValue{}, &wrapperspb.FloatValue{},
+		&wrapperspb.Int32Value{}, &wrapperspb.Int64Value{},
+		&wrapperspb.StringValue{}, &wrapperspb.UInt32Value{},
+		&wrapperspb.UInt64Value{},
+	} {
+		result[msg.ProtoReflect().Descriptor().FullName()] = true
+	}
+	return result
+}()
+
+// unwrapAny returns the message wrapped in msg if msg is a
+// google.protobuf.Any message. Otherwise, msg is returned unchanged.
+func unwrapAny(msg protoreflect.Message) (protoreflect.Message, error) {
+	if msg.Descriptor().FullName() != anypbName {
+		return msg, nil
+	}
+	unwrapped, err := msg.Interface().(*anypb.Any).UnmarshalNew()
+	if err != nil {
+		return nil, fmt.Errorf("unwrap Any: %w", err)
+	}
+	return unwrapped.ProtoReflect(), nil
+}
+
 // getProtoType returns the Go type for the protobuf type specified by the
 // protobuf kind and protobuf type name.
 // typ must be the empty string for protobuf kinds which determine the type
@@ -123,6 +172,9 @@ func go2protofd(
 		result := msg.NewField(fd).List()
 		for i := 0; i != value.Len(); i++ {
 			elemValue := value.Index(i)
+			if elemValue.Kind() == reflect.Interface && !elemValue.IsNil() {
+				elemValue = elemValue.Elem()
+			}
 			if !elemValue.Type().ConvertibleTo(elemType) {
 				return protoreflect.Value{}, fmt.Errorf(
 					"unable to convert list element %d type %s to %s",
@@ -157,6 +209,12 @@ func go2protofd(
 		result := msg.NewField(fd).Map()
 		for iter := value.MapRange(); iter.Next(); {
 			k, v := iter.Key(), iter.Value()
+			if k.Kind() == reflect.Interface && !k.IsNil() {
+				k = k.Elem()
+			}
+			if v.Kind() == reflect.Interface && !v.IsNil() {
+				v = v.Elem()
+			}
 			if !k.Type().ConvertibleTo(keyType) {
 				return protoreflect.Value{}, fmt.Errorf(
 					"unable to convert map key %v of type %s to %s",
@@ -201,3 +259,327 @@ func go2protofd(
 		return protoreflect.ValueOf(value.Convert(targetType).Interface()), nil
 	}
 }
+
+// findField returns the descriptor of the field with the given name in the
+// message described by desc. If name has the form "(full.name)", the
+// extension with that full name is looked up in the global type registry
+// instead. The extension must extend the message described by desc.
+func findField(
+	desc protoreflect.MessageDescriptor, name string,
+) (protoreflect.FieldDescriptor, error) {
+	if !strings.HasPrefix(name, "(") || !strings.HasSuffix(name, ")") {
+		fd := desc.Fields().ByName(protoreflect.Name(name))
+		if fd == nil {
+			return nil, fmt.Errorf("no such message field: %s", name)
+		}
+		return fd, nil
+	}
+	extName := protoreflect.FullName(name[1 : len(name)-1])
+	if !extName.IsValid() {
+		return nil, fmt.Errorf("invalid extension name: %s", extName)
+	}
+	xt, err := protoregistry.GlobalTypes.FindExtensionByName(extName)
+	if err != nil {
+		return nil, fmt.Errorf("find extension '%s': %w", extName, err)
+	}
+	xd := xt.TypeDescriptor()
+	if xd.ContainingMessage().FullName() != desc.FullName() {
+		return nil, fmt.Errorf("extension '%s' extends '%s', not '%s'",
+			extName, xd.ContainingMessage().FullName(), desc.FullName())
+	}
+	return xd, nil
+}
+
+// findFieldByNumber returns the descriptor of the field with the given number
+// in the message described by desc. If desc has no such field but the number
+// lies within an extension range of desc, the corresponding extension is
+// looked up in the global type registry.
+func findFieldByNumber(
+	desc protoreflect.MessageDescriptor, fn protoreflect.FieldNumber,
+) (protoreflect.FieldDescriptor, error) {
+	if fd := desc.Fields().ByNumber(fn); fd != nil {
+		return fd, nil
+	}
+	if !desc.ExtensionRanges().Has(fn) {
+		return nil, fmt.Errorf("no such message field number: %d", fn)
+	}
+	xt, err := protoregistry.GlobalTypes.FindExtensionByNumber(desc.FullName(), fn)
+	if err != nil {
+		return nil, fmt.Errorf("find extension number %d: %w", fn, err)
+	}
+	return xt.TypeDescriptor(), nil
+}
+
+// val2proto converts the given CEL value to a protobuf message of the type
+// described by desc. If the type is not linked in the binary, a dynamic
+// message is returned.
+func val2proto(
+	val ref.Val, desc protoreflect.MessageDescriptor,
+) (proto.Message, error) {
+	result, err := newProtoMessage(desc)
+	if err != nil {
+		return nil, err
+	}
+	if err := val2protomsg(val, result); err != nil {
+		return nil, err
+	}
+	return result.Interface(), nil
+}
+
+// newProtoMessage creates a new, empty message of the type described by desc.
+// If the type is not linked in the binary, a dynamic message is returned.
+func newProtoMessage(
+	desc protoreflect.MessageDescriptor,
+) (protoreflect.Message, error) {
+	msgType, err := protoregistry.GlobalTypes.FindMessageByName(desc.FullName())
+	switch {
+	case err == nil:
+		return msgType.New(), nil
+	case errors.Is(err, protoregistry.NotFound):
+		return dynamicpb.NewMessage(desc), nil
+	default:
+		return nil, fmt.Errorf("find message type '%s': %w", desc.FullName(), err)
+	}
+}
+
+// val2protomsg sets the fields of the empty message target from the given CEL
+// value. The value must be a message of the same type as target, a value
+// convertible to target if target is a well-known type (such as
+// google.protobuf.Value or google.protobuf.Any), or a map with string keys
+// naming fields of target (see findField).
+func val2protomsg(val ref.Val, target protoreflect.Message) error {
+	desc := target.Descriptor()
+	if msg, ok := val.Value().(proto.Message); ok &&
+		msg.ProtoReflect().Descriptor().FullName() == desc.FullName() {
+		proto.Merge(target.Interface(), msg)
+		return nil
+	}
+	if celWellKnownTypes[desc.FullName()] {
+		targetType := reflect.TypeOf(target.Interface())
+		if desc.FullName() == structpbName {
+			// CEL cannot convert messages to structpb.Struct directly.
+			targetType = reflect.TypeOf((*structpb.Value)(nil))
+		}
+		native, err := val.ConvertToNative(targetType)
+		if err != nil {
+			return fmt.Errorf("convert %s to %s: %w",
+				val.Type().TypeName(), desc.FullName(), err)
+		}
+		if jsonValue, ok := native.(*structpb.Value); ok &&
+			desc.FullName() == structpbName {
+			if jsonValue.GetStructValue() == nil {
+				return fmt.Errorf("convert %s to %s: not a JSON object",
+					val.Type().TypeName(), desc.FullName())
+			}
+			native = jsonValue.GetStructValue()
+		}
+		proto.Merge(target.Interface(), native.(proto.Message))
+		return nil
+	}
+	mapper, ok := val.(traits.Mapper)
+	if !ok {
+		return fmt.Errorf("cannot convert %s to message %s",
+			val.Type().TypeName(), desc.FullName())
+	}
+	for iter := mapper.Iterator(); iter.HasNext() == types.True; {
+		key := iter.Next()
+		name, ok := key.Value().(string)
+		if !ok {
+			return fmt.Errorf("map key %v is not a string", key.Value())
+		}
+		fd, err := findField(desc, name)
+		if err != nil {
+			return fmt.Errorf("field '%s' in message '%s': %w",
+				name, desc.FullName(), err)
+		}
+		oneof := fd.ContainingOneof()
+		if oneof != nil && target.WhichOneof(oneof) != nil {
+			return fmt.Errorf("multiple fields set in oneof '%s'", oneof.FullName())
+		}
+		fieldVal := mapper.Get(key)
+		if types.IsError(fieldVal) {
+			return fmt.Errorf("get field '%s' value: %w",
+				name, fieldVal.Value().(error))
+		}
+		if err := val2protofield(fieldVal, target, fd); err != nil {
+			return fmt.Errorf("field '%s': %w", name, err)
+		}
+	}
+	return nil
+}
+
+// val2protofield sets the field fd of target to the given CEL value,
+// replacing any previous value. If the value is null, the field is cleared
+// instead. Message values are converted with val2protomsg.
+func val2protofield(
+	val ref.Val, target protoreflect.Message, fd protoreflect.FieldDescriptor,
+) error {
+	if val.Type() == types.NullType {
+		target.Clear(fd)
+		return nil
+	}
+	switch {
+	case fd.IsList() && fd.Message() != nil:
+		list := target.NewField(fd).List()
+		if err := val2protolist(val, list); err != nil {
+			return err
+		}
+		target.Set(fd, protoreflect.ValueOfList(list))
+		return nil
+	case fd.IsMap() && fd.MapValue().Message() != nil:
+		m := target.NewField(fd).Map()
+		if err := val2protomap(val, m, fd); err != nil {
+			return err
+		}
+		target.Set(fd, protoreflect.ValueOfMap(m))
+		return nil
+	case fd.Message() != nil && !fd.IsMap():
+		msg := target.NewField(fd).Message()
+		if err := val2protomsg(val, msg); err != nil {
+			return err
+		}
+		target.Set(fd, protoreflect.ValueOfMessage(msg))
+		return nil
+	}
+	goval := cel2go(val)
+	if goType, err := getProtoFieldType(fd); err == nil {
+		if native, err := val.ConvertToNative(goType); err == nil {
+			goval = native
+		}
+	}
+	fieldValue, err := go2protofd(goval, target, fd)
+	if err != nil {
+		return fmt.Errorf("convert %T: %w", goval, err)
+	}
+	target.Set(fd, fieldValue)
+	return nil
+}
+
+// val2protoelem converts the given CEL value to a list element or map value
+// of the list or map field fd. newValue must be a new, empty value as
+// returned by protoreflect.List.NewElement or protoreflect.Map.NewValue.
+// Message values are converted with val2protomsg.
+func val2protoelem(
+	val ref.Val, fd protoreflect.FieldDescriptor, newValue protoreflect.Value,
+) (protoreflect.Value, error) {
+	if fd.IsMap() {
+		fd = fd.MapValue()
+	}
+	if fd.Message() != nil {
+		if err := val2protomsg(val, newValue.Message()); err != nil {
+			return protoreflect.Value{}, err
+		}
+		return newValue, nil
+	}
+	targetType := reflect.TypeOf(newValue.Interface())
+	if native, err := val.ConvertToNative(targetType); err == nil {
+		return protoreflect.ValueOf(native), nil
+	}
+	goval := reflect.ValueOf(cel2go(val))
+	if !goval.IsValid() || !goval.Type().ConvertibleTo(targetType) {
+		return protoreflect.Value{}, fmt.Errorf("value type %T not convertible to %s",
+			val.Value(), targetType)
+	}
+	return protoreflect.ValueOf(goval.Convert(targetType).Interface()), nil
+}
+
+// val2protolist appends the elements of the given CEL list value to the
+// given list of messages, see val2protomsg.
+func val2protolist(val ref.Val, target protoreflect.List) error {
+	lister, ok := val.(traits.Lister)
+	if !ok {
+		return fmt.Errorf("expected list, got %s", val.Type().TypeName())
+	}
+	for i, iter := 0, lister.Iterator(); iter.HasNext() == types.True; i++ {
+		elem := target.NewElement()
+		if err := val2protomsg(iter.Next(), elem.Message()); err != nil {
+			return fmt.Errorf("list element %d: %w", i, err)
+		}
+		target.Append(elem)
+	}
+	return nil
+}
+
+// val2protomap sets the entries of the given CEL map value in the given map
+// of messages for the map field fd, see val2protomsg.
+func val2protomap(
+	val ref.Val, target protoreflect.Map, fd protoreflect.FieldDescriptor,
+) error {
+	mapper, ok := val.(traits.Mapper)
+	if !ok {
+		return fmt.Errorf("expected map, got %s", val.Type().TypeName())
+	}
+	keyType, err := getProtoMapKeyType(Value_Kind(fd.MapKey().Kind()))
+	if err != nil {
+		return err
+	}
+	for iter := mapper.Iterator(); iter.HasNext() == types.True; {
+		key := iter.Next()
+		nativeKey, err := key.ConvertToNative(keyType)
+		if err != nil {
+			return fmt.Errorf("convert map key %v to %s: %w",
+				key.Value(), keyType, err)
+		}
+		value := target.NewValue()
+		if err := val2protomsg(mapper.Get(key), value.Message()); err != nil {
+			return fmt.Errorf("map value for key %v: %w", key.Value(), err)
+		}
+		target.Set(protoreflect.ValueOf(nativeKey).MapKey(), value)
+	}
+	return nil
+}
+
+// cel2go converts the given CEL value to a Go value. Unlike val.Value(),
+// lists and maps are converted recursively to []interface{} and
+// map[interface{}]interface{}, respectively.
+func cel2go(val ref.Val) interface{} {
+	switch x := val.(type) {
+	case traits.Lister:
+		result := make([]interface{}, 0, int(x.Size().(types.Int)))
+		for iter := x.Iterator(); iter.HasNext() == types.True; {
+			result = append(result, cel2go(iter.Next()))
+		}
+		return result
+	case traits.Mapper:
+		result := make(map[interface{}]interface{}, int(x.Size().(types.Int)))
+		for iter := x.Iterator(); iter.HasNext() == types.True; {
+			key := iter.Next()
+			result[cel2go(key)] = cel2go(x.Get(key))
+		}
+		return result
+	default:
+		return val.Value()
+	}
+}
+
+// getProtoFieldType returns the Go type of the values of the given field, as
+// returned by getProtoType and getProtoMapKeyType. For list fields, a slice
+// type is returned, and for map fields, a map type.
+func getProtoFieldType(fd protoreflect.FieldDescriptor) (reflect.Type, error) {
+	if fd.IsMap() {
+		keyType, err := getProtoMapKeyType(Value_Kind(fd.MapKey().Kind()))
+		if err != nil {
+			return nil, err
+		}
+		valueType, err := getProtoFieldType(fd.MapValue())
+		if err != nil {
+			return nil, err
+		}
+		return reflect.MapOf(keyType, valueType), nil
+	}
+	typeName := ""
+	switch fd.Kind() {
+	case protoreflect.EnumKind:
+		typeName = string(fd.Enum().FullName())
+	case protoreflect.MessageKind:
+		typeName = string(fd.Message().FullName())
+	}
+	typ, err := getProtoType(Value_Kind(fd.Kind()), typeName)
+	if err != nil {
+		return nil, err
+	}
+	if fd.IsList() {
+		return reflect.SliceOf(typ), nil
+	}
+	return typ, nil
+}
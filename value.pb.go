@@ -181,11 +181,23 @@ type Value struct {
 	// If non-empty, each element corresponds to a field, list element, or map
 	// entry selection. A string element can select a message field by name or a
 	// map entry (if the map does not have string keys, an attempt at conversion
-	// will be made). A number element can select a message field by field number,
-	// a list entry by index, or a map entry if the map has integer keys. It is an
-	// error if the number is not losslessly convertible to the corresponding
-	// integer type. A bool element can only select a map entry, and the map must
-	// have boolean keys.
+	// will be made). An extension field is selected by its full name in
+	// parentheses, e. g., "(my.pkg.ext)". The extension must be linked in the
+	// binary. A number element can select a message field (including extension
+	// fields) by field number, a list entry by index, or a map entry if the map
+	// has integer keys. It is an error if the number is not losslessly
+	// convertible to the corresponding integer type. A bool element can only
+	// select a map entry, and the map must have boolean keys.
+	//
+	// A null element is a wildcard. It selects all populated fields of a
+	// message (in declaration order), all elements of a list (in order), or all
+	// entries of a map (in ascending key order). A list element is a
+	// multi-select. Each of its elements must be a string, number, or bool
+	// element as described above, and selects one value, in order. The remaining
+	// path elements after a wildcard or multi-select are applied to each
+	// selected value. The resulting scope value is the list of all selected
+	// values. Such a list-shaped scope can be ranged over, used in CEL programs,
+	// or shifted further, in which case the path is applied to each element.
 	Scope *structpb.ListValue `protobuf:"bytes,3,opt,name=scope,proto3" json:"scope,omitempty"`
 	// value describes the actual value. If omitted, the value will be the
 	// scope value.
@@ -218,6 +230,30 @@ type Value struct {
 	//	*Value_Load
 	//	*Value_Program_
 	//	*Value_Range_
+	//	*Value_Patch_
+	//	*Value_Eq
+	//	*Value_Ne
+	//	*Value_Lt
+	//	*Value_Le
+	//	*Value_Gt
+	//	*Value_Ge
+	//	*Value_Add
+	//	*Value_Sub
+	//	*Value_Mul
+	//	*Value_Div
+	//	*Value_Mod
+	//	*Value_Neg
+	//	*Value_Transform
+	//	*Value_Filter
+	//	*Value_Count
+	//	*Value_Sum
+	//	*Value_Min
+	//	*Value_Max
+	//	*Value_Fold_
+	//	*Value_SortBy_
+	//	*Value_GroupBy_
+	//	*Value_Try_
+	//	*Value_Fail_
 	Value isValue_Value `protobuf_oneof:"value"`
 }
 
@@ -470,6 +506,174 @@ func (x *Value) GetRange() *Value_Range {
 	return nil
 }
 
+func (x *Value) GetPatch() *Value_Patch {
+	if x, ok := x.GetValue().(*Value_Patch_); ok {
+		return x.Patch
+	}
+	return nil
+}
+
+func (x *Value) GetEq() *Value_ValueList {
+	if x, ok := x.GetValue().(*Value_Eq); ok {
+		return x.Eq
+	}
+	return nil
+}
+
+func (x *Value) GetNe() *Value_ValueList {
+	if x, ok := x.GetValue().(*Value_Ne); ok {
+		return x.Ne
+	}
+	return nil
+}
+
+func (x *Value) GetLt() *Value_ValueList {
+	if x, ok := x.GetValue().(*Value_Lt); ok {
+		return x.Lt
+	}
+	return nil
+}
+
+func (x *Value) GetLe() *Value_ValueList {
+	if x, ok := x.GetValue().(*Value_Le); ok {
+		return x.Le
+	}
+	return nil
+}
+
+func (x *Value) GetGt() *Value_ValueList {
+	if x, ok := x.GetValue().(*Value_Gt); ok {
+		return x.Gt
+	}
+	return nil
+}
+
+func (x *Value) GetGe() *Value_ValueList {
+	if x, ok := x.GetValue().(*Value_Ge); ok {
+		return x.Ge
+	}
+	return nil
+}
+
+func (x *Value) GetAdd() *Value_ValueList {
+	if x, ok := x.GetValue().(*Value_Add); ok {
+		return x.Add
+	}
+	return nil
+}
+
+func (x *Value) GetSub() *Value_ValueList {
+	if x, ok := x.GetValue().(*Value_Sub); ok {
+		return x.Sub
+	}
+	return nil
+}
+
+func (x *Value) GetMul() *Value_ValueList {
+	if x, ok := x.GetValue().(*Value_Mul); ok {
+		return x.Mul
+	}
+	return nil
+}
+
+func (x *Value) GetDiv() *Value_ValueList {
+	if x, ok := x.GetValue().(*Value_Div); ok {
+		return x.Div
+	}
+	return nil
+}
+
+func (x *Value) GetMod() *Value_ValueList {
+	if x, ok := x.GetValue().(*Value_Mod); ok {
+		return x.Mod
+	}
+	return nil
+}
+
+func (x *Value) GetNeg() *Value {
+	if x, ok := x.GetValue().(*Value_Neg); ok {
+		return x.Neg
+	}
+	return nil
+}
+
+func (x *Value) GetTransform() *Value_Iteration {
+	if x, ok := x.GetValue().(*Value_Transform); ok {
+		return x.Transform
+	}
+	return nil
+}
+
+func (x *Value) GetFilter() *Value_Iteration {
+	if x, ok := x.GetValue().(*Value_Filter); ok {
+		return x.Filter
+	}
+	return nil
+}
+
+func (x *Value) GetCount() *Value_Iteration {
+	if x, ok := x.GetValue().(*Value_Count); ok {
+		return x.Count
+	}
+	return nil
+}
+
+func (x *Value) GetSum() *Value_Iteration {
+	if x, ok := x.GetValue().(*Value_Sum); ok {
+		return x.Sum
+	}
+	return nil
+}
+
+func (x *Value) GetMin() *Value_Iteration {
+	if x, ok := x.GetValue().(*Value_Min); ok {
+		return x.Min
+	}
+	return nil
+}
+
+func (x *Value) GetMax() *Value_Iteration {
+	if x, ok := x.GetValue().(*Value_Max); ok {
+		return x.Max
+	}
+	return nil
+}
+
+func (x *Value) GetFold() *Value_Fold {
+	if x, ok := x.GetValue().(*Value_Fold_); ok {
+		return x.Fold
+	}
+	return nil
+}
+
+func (x *Value) GetSortBy() *Value_SortBy {
+	if x, ok := x.GetValue().(*Value_SortBy_); ok {
+		return x.SortBy
+	}
+	return nil
+}
+
+func (x *Value) GetGroupBy() *Value_GroupBy {
+	if x, ok := x.GetValue().(*Value_GroupBy_); ok {
+		return x.GroupBy
+	}
+	return nil
+}
+
+func (x *Value) GetTry() *Value_Try {
+	if x, ok := x.GetValue().(*Value_Try_); ok {
+		return x.Try
+	}
+	return nil
+}
+
+func (x *Value) GetFail() *Value_Fail {
+	if x, ok := x.GetValue().(*Value_Fail_); ok {
+		return x.Fail
+	}
+	return nil
+}
+
 type isValue_Value interface {
 	isValue_Value()
 }
@@ -602,16 +806,21 @@ type Value_While struct {
 }
 
 type Value_Break struct {
-	// break breaks out of the given number of while evaluations.
-	// while evaluations where break occurs in the conditional part do not
-	// count.
+	// break breaks out of the given number of while or range evaluations.
+	// while evaluations where break occurs in the conditional part, and range
+	// evaluations where break occurs in the iterable, do not count.
+	// A range broken out of yields the value of the evaluation interrupted by
+	// the break (e. g., the value of the last evaluated element of a seq), or
+	// null.
 	Break uint32 `protobuf:"varint,24,opt,name=break,proto3,oneof"`
 }
 
 type Value_Continue struct {
-	// continue continues the given nth enclosing while evaluation.
+	// continue continues the given nth enclosing while or range evaluation.
 	// while evaluations where continue occurs in the conditional
-	// part do not count.
+	// part, and range evaluations where continue occurs in the iterable, do
+	// not count. A range continued with proceeds with the next element,
+	// discarding the value of the evaluation interrupted by the continue.
 	Continue uint32 `protobuf:"varint,25,opt,name=continue,proto3,oneof"`
 }
 
@@ -647,6 +856,153 @@ type Value_Range_ struct {
 	Range *Value_Range `protobuf:"bytes,30,opt,name=range,proto3,oneof"`
 }
 
+type Value_Patch_ struct {
+	// patch yields a modified copy of the scope value, which must be a
+	// message. See the Patch documentation for details.
+	Patch *Value_Patch `protobuf:"bytes,31,opt,name=patch,proto3,oneof"`
+}
+
+type Value_Eq struct {
+	// eq yields true if the operands are equal, and false otherwise.
+	Eq *Value_ValueList `protobuf:"bytes,32,opt,name=eq,proto3,oneof"`
+}
+
+type Value_Ne struct {
+	// ne yields true if the operands are not equal, and false otherwise.
+	Ne *Value_ValueList `protobuf:"bytes,33,opt,name=ne,proto3,oneof"`
+}
+
+type Value_Lt struct {
+	// lt yields true if the first operand is less than the second operand.
+	Lt *Value_ValueList `protobuf:"bytes,34,opt,name=lt,proto3,oneof"`
+}
+
+type Value_Le struct {
+	// le yields true if the first operand is less than or equal to the second
+	// operand.
+	Le *Value_ValueList `protobuf:"bytes,35,opt,name=le,proto3,oneof"`
+}
+
+type Value_Gt struct {
+	// gt yields true if the first operand is greater than the second operand.
+	Gt *Value_ValueList `protobuf:"bytes,36,opt,name=gt,proto3,oneof"`
+}
+
+type Value_Ge struct {
+	// ge yields true if the first operand is greater than or equal to the
+	// second operand.
+	Ge *Value_ValueList `protobuf:"bytes,37,opt,name=ge,proto3,oneof"`
+}
+
+type Value_Add struct {
+	// add yields the sum of the operands. For strings, bytes, and lists, it
+	// yields their concatenation.
+	Add *Value_ValueList `protobuf:"bytes,38,opt,name=add,proto3,oneof"`
+}
+
+type Value_Sub struct {
+	// sub yields the difference of the operands.
+	Sub *Value_ValueList `protobuf:"bytes,39,opt,name=sub,proto3,oneof"`
+}
+
+type Value_Mul struct {
+	// mul yields the product of the operands.
+	Mul *Value_ValueList `protobuf:"bytes,40,opt,name=mul,proto3,oneof"`
+}
+
+type Value_Div struct {
+	// div yields the quotient of the operands.
+	Div *Value_ValueList `protobuf:"bytes,41,opt,name=div,proto3,oneof"`
+}
+
+type Value_Mod struct {
+	// mod yields the remainder of the division of the operands.
+	Mod *Value_ValueList `protobuf:"bytes,42,opt,name=mod,proto3,oneof"`
+}
+
+type Value_Neg struct {
+	// neg yields the negation of the specified value based on scope, which
+	// must be numeric or a duration.
+	Neg *Value `protobuf:"bytes,43,opt,name=neg,proto3,oneof"`
+}
+
+type Value_Transform struct {
+	// transform yields a list of the values obtained by evaluating the
+	// iteration value for each element of a list iterable, or a map with the
+	// same keys as a map iterable and the values obtained by evaluating the
+	// iteration value for each entry. If the iteration value is omitted, the
+	// iterable elements are used unchanged.
+	Transform *Value_Iteration `protobuf:"bytes,44,opt,name=transform,proto3,oneof"`
+}
+
+type Value_Filter struct {
+	// filter yields a list of the elements of a list iterable, or a map of
+	// the entries of a map iterable, for which the iteration value yields
+	// true. The iteration value must yield a boolean value.
+	Filter *Value_Iteration `protobuf:"bytes,45,opt,name=filter,proto3,oneof"`
+}
+
+type Value_Count struct {
+	// count yields the number of elements of the iterable for which the
+	// iteration value yields true. The iteration value must yield a boolean
+	// value. If it is omitted, all elements are counted.
+	Count *Value_Iteration `protobuf:"bytes,46,opt,name=count,proto3,oneof"`
+}
+
+type Value_Sum struct {
+	// sum yields the sum, as by the add operator, of the values obtained by
+	// evaluating the iteration value for each element. If the iteration value
+	// is omitted, the element values are summed. If the iterable is empty,
+	// sum yields the integer zero.
+	Sum *Value_Iteration `protobuf:"bytes,47,opt,name=sum,proto3,oneof"`
+}
+
+type Value_Min struct {
+	// min yields the least value obtained by evaluating the iteration value
+	// for each element, compared as by the lt operator. If the iteration
+	// value is omitted, the element values are compared. If the iterable is
+	// empty, min yields null.
+	Min *Value_Iteration `protobuf:"bytes,48,opt,name=min,proto3,oneof"`
+}
+
+type Value_Max struct {
+	// max yields the greatest value obtained by evaluating the iteration
+	// value for each element, compared as by the gt operator. If the
+	// iteration value is omitted, the element values are compared. If the
+	// iterable is empty, max yields null.
+	Max *Value_Iteration `protobuf:"bytes,49,opt,name=max,proto3,oneof"`
+}
+
+type Value_Fold_ struct {
+	// fold combines the elements of an aggregate into a single value. See the
+	// Fold documentation for details.
+	Fold *Value_Fold `protobuf:"bytes,50,opt,name=fold,proto3,oneof"`
+}
+
+type Value_SortBy_ struct {
+	// sort_by yields a sorted copy of a list. See the SortBy documentation
+	// for details.
+	SortBy *Value_SortBy `protobuf:"bytes,51,opt,name=sort_by,json=sortBy,proto3,oneof"`
+}
+
+type Value_GroupBy_ struct {
+	// group_by groups the elements of an aggregate into a map of lists. See
+	// the GroupBy documentation for details.
+	GroupBy *Value_GroupBy `protobuf:"bytes,52,opt,name=group_by,json=groupBy,proto3,oneof"`
+}
+
+type Value_Try_ struct {
+	// try yields the value of a body, or of a fallback if the body fails.
+	// See the Try documentation for details.
+	Try *Value_Try `protobuf:"bytes,53,opt,name=try,proto3,oneof"`
+}
+
+type Value_Fail_ struct {
+	// fail fails the evaluation with a user-defined error. Unless handled by
+	// an enclosing try, the evaluation as a whole fails.
+	Fail *Value_Fail `protobuf:"bytes,54,opt,name=fail,proto3,oneof"`
+}
+
 func (*Value_Arg) isValue_Value() {}
 
 func (*Value_Parent) isValue_Value() {}
@@ -701,8 +1057,60 @@ func (*Value_Program_) isValue_Value() {}
 
 func (*Value_Range_) isValue_Value() {}
 
-// Scope describes a scope for CEL programs. It can be used for more complex
-// message access. The Scope message is not directly used in the Value message.
+func (*Value_Patch_) isValue_Value() {}
+
+func (*Value_Eq) isValue_Value() {}
+
+func (*Value_Ne) isValue_Value() {}
+
+func (*Value_Lt) isValue_Value() {}
+
+func (*Value_Le) isValue_Value() {}
+
+func (*Value_Gt) isValue_Value() {}
+
+func (*Value_Ge) isValue_Value() {}
+
+func (*Value_Add) isValue_Value() {}
+
+func (*Value_Sub) isValue_Value() {}
+
+func (*Value_Mul) isValue_Value() {}
+
+func (*Value_Div) isValue_Value() {}
+
+func (*Value_Mod) isValue_Value() {}
+
+func (*Value_Neg) isValue_Value() {}
+
+func (*Value_Transform) isValue_Value() {}
+
+func (*Value_Filter) isValue_Value() {}
+
+func (*Value_Count) isValue_Value() {}
+
+func (*Value_Sum) isValue_Value() {}
+
+func (*Value_Min) isValue_Value() {}
+
+func (*Value_Max) isValue_Value() {}
+
+func (*Value_Fold_) isValue_Value() {}
+
+func (*Value_SortBy_) isValue_Value() {}
+
+func (*Value_GroupBy_) isValue_Value() {}
+
+func (*Value_Try_) isValue_Value() {}
+
+func (*Value_Fail_) isValue_Value() {}
+
+// Scope describes a scope for CEL programs as a message.
+//
+// Deprecated: CEL programs see the scope natively, see Value.Program. Scope
+// is not used anymore and will be removed in a future version.
+//
+// Deprecated: Do not use.
 type Scope struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -717,30 +1125,16 @@ type Scope struct {
 	// value is the scope value for messages and scalars. For scalars, the
 	// google.protobuf wrappers types will be used. Enum values will be
 	// converted to google.protobuf.Int32Value.
-	// CEL automatically converts the Any and wrapper types to their proper
-	// message or scalar types.
 	// If the scope value is not a message or a scalar, value will be omitted.
 	Value *anypb.Any `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`
 	// list is the scope value for lists. If the list element type is a scalar,
 	// a google.protobuf wrappers type will be used.
-	// CEL automatically converts the Any and wrapper types to their proper
-	// message or scalar types.
 	// If the scope value is not a list, list will be omitted.
 	List []*anypb.Any `protobuf:"bytes,4,rep,name=list,proto3" json:"list,omitempty"`
 	// map is the scope value for maps. If the map key type is not a string, the
-	// keys will be converted to a string as follows:
-	//
-	//     /-----------------------------------------\
-	//     | Key     | String representation of Key  |
-	//     |=========|===============================|
-	//     | false   | "False"                       |
-	//     | true    | "True"                        |
-	//     | integer | The integer as decimal string |
-	//     \-----------------------------------------/
-	//
-	// If the map value type is a scalar, a google.protobuf wrappers type will be
-	// used. CEL automatically converts the Any and wrapper types to their proper
-	// message or scalar types.
+	// keys will be converted to a string: "False" or "True" for bool keys, and
+	// the decimal representation for integer keys. If the map value type is a
+	// scalar, a google.protobuf wrappers type will be used.
 	// If the scope value is not a map, map will be omitted.
 	Map map[string]*anypb.Any `protobuf:"bytes,5,rep,name=map,proto3" json:"map,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 }
@@ -1130,8 +1524,24 @@ type Value_Message struct {
 	// type is the full name of the message type. Required.
 	Type string `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
 	// fields describes the message fields. The types of the values must match
-	// the message field types.
+	// the message field types. Extension fields are specified by their full
+	// name in parentheses, e. g., "(my.pkg.ext)".
 	Fields map[string]*Value `protobuf:"bytes,2,rep,name=fields,proto3" json:"fields,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// clear lists paths to clear after the fields have been set. Each path
+	// selects a message field, list element, or map entry within the message,
+	// like Patch.Operation.path. A selected message field is cleared, a list
+	// element is removed (subsequent elements move up), and a map entry is
+	// removed. Each path is validated against the message descriptor. If the
+	// path leads through an unset message field or a missing map entry, it
+	// has no effect. A list index out of bounds is an error.
+	Clear []*structpb.ListValue `protobuf:"bytes,3,rep,name=clear,proto3" json:"clear,omitempty"`
+	// base is an optional value the message is constructed from. It must
+	// yield a message of the given type, or a map with string keys naming the
+	// message fields. The base is merged into the new message before the
+	// fields are set, as with proto.Merge. Fields override the corresponding
+	// base fields, including other fields in the same oneof. If base yields
+	// null, the message is constructed from scratch.
+	Base *Value `protobuf:"bytes,4,opt,name=base,proto3" json:"base,omitempty"`
 }
 
 func (x *Value_Message) Reset() {
@@ -1180,7 +1590,89 @@ func (x *Value_Message) GetFields() map[string]*Value {
 	return nil
 }
 
+func (x *Value_Message) GetClear() []*structpb.ListValue {
+	if x != nil {
+		return x.Clear
+	}
+	return nil
+}
+
+func (x *Value_Message) GetBase() *Value {
+	if x != nil {
+		return x.Base
+	}
+	return nil
+}
+
+// Patch describes modifications to a copy of a message. The operations are
+// applied to the copy in order. The values in the operations are evaluated
+// based on the scope of the Value the Patch belongs to, i. e., they see the
+// original message, not the copy.
+type Value_Patch struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// operations is the list of operations.
+	Operations []*Value_Patch_Operation `protobuf:"bytes,1,rep,name=operations,proto3" json:"operations,omitempty"`
+}
+
+func (x *Value_Patch) Reset() {
+	*x = Value_Patch{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protoeval_value_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Value_Patch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Value_Patch) ProtoMessage() {}
+
+func (x *Value_Patch) ProtoReflect() protoreflect.Message {
+	mi := &file_protoeval_value_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Value_Patch.ProtoReflect.Descriptor instead.
+func (*Value_Patch) Descriptor() ([]byte, []int) {
+	return file_protoeval_value_proto_rawDescGZIP(), []int{0, 5}
+}
+
+func (x *Value_Patch) GetOperations() []*Value_Patch_Operation {
+	if x != nil {
+		return x.Operations
+	}
+	return nil
+}
+
 // Program describes a CEL program.
+//
+// The program has access to the following variables:
+//
+//   - env is a map with string keys holding the environment values.
+//   - args is the list of arguments, with args[0] being the 0-th argument.
+//   - scope is a map describing the current scope. scope.value is the scope
+//     value with its native type, i. e., messages have their actual message
+//     type, and lists and maps are typed (maps retain their key types).
+//     If the scope value is a list (this includes list-shaped scopes created
+//     by wildcard or multi-select scope selection paths), scope.list is the
+//     same as scope.value. If the scope value is a map, scope.map is the same
+//     as scope.value. Unless the scope is the root scope, scope.parent is
+//     the parent scope, and scope.field_descriptor is the
+//     google.protobuf.FieldDescriptorProto of the field leading up to the
+//     scope value. Keys which are not present can be tested for with has(),
+//     e. g., has(scope.parent).
 type Value_Program struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -1200,7 +1692,7 @@ type Value_Program struct {
 func (x *Value_Program) Reset() {
 	*x = Value_Program{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_protoeval_value_proto_msgTypes[7]
+		mi := &file_protoeval_value_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1213,7 +1705,7 @@ func (x *Value_Program) String() string {
 func (*Value_Program) ProtoMessage() {}
 
 func (x *Value_Program) ProtoReflect() protoreflect.Message {
-	mi := &file_protoeval_value_proto_msgTypes[7]
+	mi := &file_protoeval_value_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1226,7 +1718,7 @@ func (x *Value_Program) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Value_Program.ProtoReflect.Descriptor instead.
 func (*Value_Program) Descriptor() ([]byte, []int) {
-	return file_protoeval_value_proto_rawDescGZIP(), []int{0, 5}
+	return file_protoeval_value_proto_rawDescGZIP(), []int{0, 6}
 }
 
 func (x *Value_Program) GetCode() string {
@@ -1261,18 +1753,26 @@ type Value_Range struct {
 	// argument is the map key, and the 1st argument is the map value.
 	//
 	// A list will be evaluated in order. The evaluation order for a map is
-	// unspecified.
+	// unspecified, unless sorted_keys is set or sorted map iteration is
+	// enabled in the evaluation environment.
 	//
 	// If an evaluation yields a value other than null, evaluation
 	// stops early and the range yields that value. Otherwise, the range
 	// yields null.
 	Value *Value `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	// sorted_keys causes a map to be evaluated in ascending key order: false
+	// before true, integers in numeric order, and strings in bytewise
+	// lexicographical order of their UTF-8 encoding. Keys of different
+	// types (only possible with maps not stemming from a protobuf message) are
+	// ordered by type first, in the order just given, with signed integers
+	// before unsigned integers.
+	SortedKeys bool `protobuf:"varint,3,opt,name=sorted_keys,json=sortedKeys,proto3" json:"sorted_keys,omitempty"`
 }
 
 func (x *Value_Range) Reset() {
 	*x = Value_Range{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_protoeval_value_proto_msgTypes[8]
+		mi := &file_protoeval_value_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1285,7 +1785,7 @@ func (x *Value_Range) String() string {
 func (*Value_Range) ProtoMessage() {}
 
 func (x *Value_Range) ProtoReflect() protoreflect.Message {
-	mi := &file_protoeval_value_proto_msgTypes[8]
+	mi := &file_protoeval_value_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1298,7 +1798,7 @@ func (x *Value_Range) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Value_Range.ProtoReflect.Descriptor instead.
 func (*Value_Range) Descriptor() ([]byte, []int) {
-	return file_protoeval_value_proto_rawDescGZIP(), []int{0, 6}
+	return file_protoeval_value_proto_rawDescGZIP(), []int{0, 7}
 }
 
 func (x *Value_Range) GetIterable() *Value {
@@ -1315,6 +1815,13 @@ func (x *Value_Range) GetValue() *Value {
 	return nil
 }
 
+func (x *Value_Range) GetSortedKeys() bool {
+	if x != nil {
+		return x.SortedKeys
+	}
+	return false
+}
+
 // StoredValue describes a value stored in the environment.
 type Value_StoredValue struct {
 	state         protoimpl.MessageState
@@ -1330,7 +1837,7 @@ type Value_StoredValue struct {
 func (x *Value_StoredValue) Reset() {
 	*x = Value_StoredValue{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_protoeval_value_proto_msgTypes[9]
+		mi := &file_protoeval_value_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1343,7 +1850,7 @@ func (x *Value_StoredValue) String() string {
 func (*Value_StoredValue) ProtoMessage() {}
 
 func (x *Value_StoredValue) ProtoReflect() protoreflect.Message {
-	mi := &file_protoeval_value_proto_msgTypes[9]
+	mi := &file_protoeval_value_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1356,7 +1863,7 @@ func (x *Value_StoredValue) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Value_StoredValue.ProtoReflect.Descriptor instead.
 func (*Value_StoredValue) Descriptor() ([]byte, []int) {
-	return file_protoeval_value_proto_rawDescGZIP(), []int{0, 7}
+	return file_protoeval_value_proto_rawDescGZIP(), []int{0, 8}
 }
 
 func (x *Value_StoredValue) GetKey() *Value {
@@ -1391,7 +1898,7 @@ type Value_Switch struct {
 func (x *Value_Switch) Reset() {
 	*x = Value_Switch{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_protoeval_value_proto_msgTypes[10]
+		mi := &file_protoeval_value_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1404,7 +1911,7 @@ func (x *Value_Switch) String() string {
 func (*Value_Switch) ProtoMessage() {}
 
 func (x *Value_Switch) ProtoReflect() protoreflect.Message {
-	mi := &file_protoeval_value_proto_msgTypes[10]
+	mi := &file_protoeval_value_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1417,7 +1924,7 @@ func (x *Value_Switch) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Value_Switch.ProtoReflect.Descriptor instead.
 func (*Value_Switch) Descriptor() ([]byte, []int) {
-	return file_protoeval_value_proto_rawDescGZIP(), []int{0, 8}
+	return file_protoeval_value_proto_rawDescGZIP(), []int{0, 9}
 }
 
 func (x *Value_Switch) GetCases() []*Value_Branch {
@@ -1434,21 +1941,433 @@ func (x *Value_Switch) GetDefault() *Value {
 	return nil
 }
 
-// ValueList is a list of values, not necessarily of the same type (though
-// users of ValueList often prescribe certain type constraints).
-type Value_ValueList struct {
+// Iteration describes an evaluation for each element of an aggregate value,
+// i. e., a list or a map.
+type Value_Iteration struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// values is the list of values.
-	Values []*Value `protobuf:"bytes,1,rep,name=values,proto3" json:"values,omitempty"`
+	// iterable is the iterable value. If omitted, the scope value is used
+	// instead. It is an error if this value is not an aggregate.
+	Iterable *Value `protobuf:"bytes,1,opt,name=iterable,proto3" json:"iterable,omitempty"`
+	// value is evaluated for each element in the iterable value, with the
+	// same arguments as for Range.value. A list will be evaluated in order.
+	// The evaluation order for a map is unspecified, unless sorted map
+	// iteration is enabled in the evaluation environment, in which case the
+	// order is as for Range.sorted_keys. Whether value is
+	// required depends on the Value kind the Iteration belongs to.
+	Value *Value `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
 }
 
-func (x *Value_ValueList) Reset() {
-	*x = Value_ValueList{}
+func (x *Value_Iteration) Reset() {
+	*x = Value_Iteration{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_protoeval_value_proto_msgTypes[11]
+		mi := &file_protoeval_value_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Value_Iteration) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Value_Iteration) ProtoMessage() {}
+
+func (x *Value_Iteration) ProtoReflect() protoreflect.Message {
+	mi := &file_protoeval_value_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Value_Iteration.ProtoReflect.Descriptor instead.
+func (*Value_Iteration) Descriptor() ([]byte, []int) {
+	return file_protoeval_value_proto_rawDescGZIP(), []int{0, 10}
+}
+
+func (x *Value_Iteration) GetIterable() *Value {
+	if x != nil {
+		return x.Iterable
+	}
+	return nil
+}
+
+func (x *Value_Iteration) GetValue() *Value {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+// Fold describes a value obtained by folding an aggregate value, i. e., a
+// list or a map, into a single value.
+type Value_Fold struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// iterable is the iterable value. If omitted, the scope value is used
+	// instead. It is an error if this value is not an aggregate.
+	Iterable *Value `protobuf:"bytes,1,opt,name=iterable,proto3" json:"iterable,omitempty"`
+	// init is the initial accumulator value. If omitted, the initial
+	// accumulator value is null.
+	Init *Value `protobuf:"bytes,2,opt,name=init,proto3" json:"init,omitempty"`
+	// value is evaluated for each element in the iterable value, with the
+	// same arguments as for Range.value, plus the current accumulator value
+	// as the 2nd argument. The result becomes the new accumulator value.
+	// A list will be evaluated in order. The evaluation order for a map is
+	// as for Iteration.value. Required.
+	//
+	// The fold yields the final accumulator value.
+	Value *Value `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`
+}
+
+func (x *Value_Fold) Reset() {
+	*x = Value_Fold{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protoeval_value_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Value_Fold) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Value_Fold) ProtoMessage() {}
+
+func (x *Value_Fold) ProtoReflect() protoreflect.Message {
+	mi := &file_protoeval_value_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Value_Fold.ProtoReflect.Descriptor instead.
+func (*Value_Fold) Descriptor() ([]byte, []int) {
+	return file_protoeval_value_proto_rawDescGZIP(), []int{0, 11}
+}
+
+func (x *Value_Fold) GetIterable() *Value {
+	if x != nil {
+		return x.Iterable
+	}
+	return nil
+}
+
+func (x *Value_Fold) GetInit() *Value {
+	if x != nil {
+		return x.Init
+	}
+	return nil
+}
+
+func (x *Value_Fold) GetValue() *Value {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+// SortBy describes a list sorted by keys. The sort is stable, i. e.,
+// elements with equal keys retain their relative order.
+type Value_SortBy struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// iterable is the list to be sorted. If omitted, the scope value is used
+	// instead. It is an error if this value is not a list.
+	Iterable *Value `protobuf:"bytes,1,opt,name=iterable,proto3" json:"iterable,omitempty"`
+	// keys are the sort keys. Elements are ordered by the first key, then,
+	// if the first keys are equal, by the second key, etc. If keys is empty,
+	// the elements are sorted in ascending order of the element values.
+	Keys []*Value_SortBy_Key `protobuf:"bytes,2,rep,name=keys,proto3" json:"keys,omitempty"`
+}
+
+func (x *Value_SortBy) Reset() {
+	*x = Value_SortBy{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protoeval_value_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Value_SortBy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Value_SortBy) ProtoMessage() {}
+
+func (x *Value_SortBy) ProtoReflect() protoreflect.Message {
+	mi := &file_protoeval_value_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Value_SortBy.ProtoReflect.Descriptor instead.
+func (*Value_SortBy) Descriptor() ([]byte, []int) {
+	return file_protoeval_value_proto_rawDescGZIP(), []int{0, 12}
+}
+
+func (x *Value_SortBy) GetIterable() *Value {
+	if x != nil {
+		return x.Iterable
+	}
+	return nil
+}
+
+func (x *Value_SortBy) GetKeys() []*Value_SortBy_Key {
+	if x != nil {
+		return x.Keys
+	}
+	return nil
+}
+
+// GroupBy describes a map from keys to groups of elements of an aggregate
+// value, i. e., a list or a map.
+type Value_GroupBy struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// iterable is the iterable value. If omitted, the scope value is used
+	// instead. It is an error if this value is not an aggregate.
+	Iterable *Value `protobuf:"bytes,1,opt,name=iterable,proto3" json:"iterable,omitempty"`
+	// key is evaluated for each element in the iterable value, with the same
+	// arguments as for Range.value, and yields the key of the group the
+	// element belongs to. The key must be a string, integer, or boolean
+	// value. Required.
+	Key *Value `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	// value is evaluated for each element in the iterable value, with the
+	// same arguments as for Range.value, and yields the value added to the
+	// group of the element. If omitted, the element value itself is added.
+	//
+	// Each group is a list of values. For a list iterable, the values appear
+	// in list order. For a map iterable, the order is as for
+	// Iteration.value.
+	Value *Value `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`
+}
+
+func (x *Value_GroupBy) Reset() {
+	*x = Value_GroupBy{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protoeval_value_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Value_GroupBy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Value_GroupBy) ProtoMessage() {}
+
+func (x *Value_GroupBy) ProtoReflect() protoreflect.Message {
+	mi := &file_protoeval_value_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Value_GroupBy.ProtoReflect.Descriptor instead.
+func (*Value_GroupBy) Descriptor() ([]byte, []int) {
+	return file_protoeval_value_proto_rawDescGZIP(), []int{0, 13}
+}
+
+func (x *Value_GroupBy) GetIterable() *Value {
+	if x != nil {
+		return x.Iterable
+	}
+	return nil
+}
+
+func (x *Value_GroupBy) GetKey() *Value {
+	if x != nil {
+		return x.Key
+	}
+	return nil
+}
+
+func (x *Value_GroupBy) GetValue() *Value {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+// Try describes a value with error handling.
+type Value_Try struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// body is the value yielded if its evaluation succeeds. Required.
+	Body *Value `protobuf:"bytes,1,opt,name=body,proto3" json:"body,omitempty"`
+	// fallback is evaluated if the evaluation of body fails, and the try
+	// yields its value. The fallback is evaluated with one additional
+	// argument: a map with the following string keys describing the error:
+	//
+	//   - "message": the error message.
+	//   - "kind": the error kind, a string. This is "fail" for errors from a
+	//     fail Value, "cel" for CEL runtime errors (e. g., division by zero or
+	//     missing overloads), and "eval" for all other evaluation errors
+	//     (e. g., scope selection or conversion failures).
+	//   - "code": the code of the Fail value for the "fail" kind, otherwise
+	//     the empty string.
+	//
+	// If omitted, the try yields null if body fails.
+	//
+	// Exceeding the maximum evaluation length, as well as break and
+	// continue, are not considered failures.
+	Fallback *Value `protobuf:"bytes,2,opt,name=fallback,proto3" json:"fallback,omitempty"`
+}
+
+func (x *Value_Try) Reset() {
+	*x = Value_Try{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protoeval_value_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Value_Try) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Value_Try) ProtoMessage() {}
+
+func (x *Value_Try) ProtoReflect() protoreflect.Message {
+	mi := &file_protoeval_value_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Value_Try.ProtoReflect.Descriptor instead.
+func (*Value_Try) Descriptor() ([]byte, []int) {
+	return file_protoeval_value_proto_rawDescGZIP(), []int{0, 14}
+}
+
+func (x *Value_Try) GetBody() *Value {
+	if x != nil {
+		return x.Body
+	}
+	return nil
+}
+
+func (x *Value_Try) GetFallback() *Value {
+	if x != nil {
+		return x.Fallback
+	}
+	return nil
+}
+
+// Fail describes a user-defined error.
+type Value_Fail struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// code is a user-defined error code.
+	Code string `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	// message is the error message. It must yield a string. Optional.
+	Message *Value `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+}
+
+func (x *Value_Fail) Reset() {
+	*x = Value_Fail{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protoeval_value_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Value_Fail) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Value_Fail) ProtoMessage() {}
+
+func (x *Value_Fail) ProtoReflect() protoreflect.Message {
+	mi := &file_protoeval_value_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Value_Fail.ProtoReflect.Descriptor instead.
+func (*Value_Fail) Descriptor() ([]byte, []int) {
+	return file_protoeval_value_proto_rawDescGZIP(), []int{0, 15}
+}
+
+func (x *Value_Fail) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *Value_Fail) GetMessage() *Value {
+	if x != nil {
+		return x.Message
+	}
+	return nil
+}
+
+// ValueList is a list of values, not necessarily of the same type (though
+// users of ValueList often prescribe certain type constraints).
+type Value_ValueList struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// values is the list of values.
+	Values []*Value `protobuf:"bytes,1,rep,name=values,proto3" json:"values,omitempty"`
+}
+
+func (x *Value_ValueList) Reset() {
+	*x = Value_ValueList{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protoeval_value_proto_msgTypes[18]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1458,10 +2377,135 @@ func (x *Value_ValueList) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Value_ValueList) ProtoMessage() {}
+func (*Value_ValueList) ProtoMessage() {}
+
+func (x *Value_ValueList) ProtoReflect() protoreflect.Message {
+	mi := &file_protoeval_value_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Value_ValueList.ProtoReflect.Descriptor instead.
+func (*Value_ValueList) Descriptor() ([]byte, []int) {
+	return file_protoeval_value_proto_rawDescGZIP(), []int{0, 16}
+}
+
+func (x *Value_ValueList) GetValues() []*Value {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+// Entry describes a map entry.
+type Value_Map_Entry struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// key is the the of this entry. Required.
+	Key *Value `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	// value is the value of this entry. Required.
+	Value *Value `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+}
+
+func (x *Value_Map_Entry) Reset() {
+	*x = Value_Map_Entry{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protoeval_value_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Value_Map_Entry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Value_Map_Entry) ProtoMessage() {}
+
+func (x *Value_Map_Entry) ProtoReflect() protoreflect.Message {
+	mi := &file_protoeval_value_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Value_Map_Entry.ProtoReflect.Descriptor instead.
+func (*Value_Map_Entry) Descriptor() ([]byte, []int) {
+	return file_protoeval_value_proto_rawDescGZIP(), []int{0, 3, 0}
+}
+
+func (x *Value_Map_Entry) GetKey() *Value {
+	if x != nil {
+		return x.Key
+	}
+	return nil
+}
+
+func (x *Value_Map_Entry) GetValue() *Value {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+// Operation describes a single modification.
+type Value_Patch_Operation struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// path selects the target of this operation within the copy, like
+	// Value.scope selects a scope: message fields are selected by name
+	// (extension fields by their full name in parentheses) or by number,
+	// list elements by index, and map entries by key. Wildcard and
+	// multi-select elements are not permitted. Required, must not be empty.
+	//
+	// For set and append, unset message fields along the path are created
+	// as needed. For clear, if the path leads through an unset message
+	// field or a missing map entry, the operation has no effect.
+	Path *structpb.ListValue `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	// when is an optional condition. It must yield a boolean value. If it
+	// yields false, this operation is skipped.
+	When *Value `protobuf:"bytes,2,opt,name=when,proto3" json:"when,omitempty"`
+	// op is the operation to perform. Required.
+	//
+	// Types that are assignable to Op:
+	//	*Value_Patch_Operation_Set
+	//	*Value_Patch_Operation_Clear
+	//	*Value_Patch_Operation_Append
+	Op isValue_Patch_Operation_Op `protobuf_oneof:"op"`
+}
+
+func (x *Value_Patch_Operation) Reset() {
+	*x = Value_Patch_Operation{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protoeval_value_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Value_Patch_Operation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Value_Patch_Operation) ProtoMessage() {}
 
-func (x *Value_ValueList) ProtoReflect() protoreflect.Message {
-	mi := &file_protoeval_value_proto_msgTypes[11]
+func (x *Value_Patch_Operation) ProtoReflect() protoreflect.Message {
+	mi := &file_protoeval_value_proto_msgTypes[21]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1472,47 +2516,118 @@ func (x *Value_ValueList) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Value_ValueList.ProtoReflect.Descriptor instead.
-func (*Value_ValueList) Descriptor() ([]byte, []int) {
-	return file_protoeval_value_proto_rawDescGZIP(), []int{0, 9}
+// Deprecated: Use Value_Patch_Operation.ProtoReflect.Descriptor instead.
+func (*Value_Patch_Operation) Descriptor() ([]byte, []int) {
+	return file_protoeval_value_proto_rawDescGZIP(), []int{0, 5, 0}
 }
 
-func (x *Value_ValueList) GetValues() []*Value {
+func (x *Value_Patch_Operation) GetPath() *structpb.ListValue {
 	if x != nil {
-		return x.Values
+		return x.Path
 	}
 	return nil
 }
 
-// Entry describes a map entry.
-type Value_Map_Entry struct {
+func (x *Value_Patch_Operation) GetWhen() *Value {
+	if x != nil {
+		return x.When
+	}
+	return nil
+}
+
+func (m *Value_Patch_Operation) GetOp() isValue_Patch_Operation_Op {
+	if m != nil {
+		return m.Op
+	}
+	return nil
+}
+
+func (x *Value_Patch_Operation) GetSet() *Value {
+	if x, ok := x.GetOp().(*Value_Patch_Operation_Set); ok {
+		return x.Set
+	}
+	return nil
+}
+
+func (x *Value_Patch_Operation) GetClear() *emptypb.Empty {
+	if x, ok := x.GetOp().(*Value_Patch_Operation_Clear); ok {
+		return x.Clear
+	}
+	return nil
+}
+
+func (x *Value_Patch_Operation) GetAppend() *Value {
+	if x, ok := x.GetOp().(*Value_Patch_Operation_Append); ok {
+		return x.Append
+	}
+	return nil
+}
+
+type isValue_Patch_Operation_Op interface {
+	isValue_Patch_Operation_Op()
+}
+
+type Value_Patch_Operation_Set struct {
+	// set sets the target to the given value. The target can be a message
+	// field, a list element, or a map entry (which is added if missing).
+	// A message value can also be specified as a map with string keys
+	// naming the message fields. If the value is null, set is the same as
+	// clear for message fields.
+	Set *Value `protobuf:"bytes,3,opt,name=set,proto3,oneof"`
+}
+
+type Value_Patch_Operation_Clear struct {
+	// clear clears the target message field, removes the target list
+	// element (subsequent elements move up), or removes the target map
+	// entry.
+	Clear *emptypb.Empty `protobuf:"bytes,4,opt,name=clear,proto3,oneof"`
+}
+
+type Value_Patch_Operation_Append struct {
+	// append appends the given value as a new element to the target,
+	// which must be a list field.
+	Append *Value `protobuf:"bytes,5,opt,name=append,proto3,oneof"`
+}
+
+func (*Value_Patch_Operation_Set) isValue_Patch_Operation_Op() {}
+
+func (*Value_Patch_Operation_Clear) isValue_Patch_Operation_Op() {}
+
+func (*Value_Patch_Operation_Append) isValue_Patch_Operation_Op() {}
+
+// Key describes a sort key.
+type Value_SortBy_Key struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// key is the the of this entry. Required.
-	Key *Value `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
-	// value is the value of this entry. Required.
-	Value *Value `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	// value is evaluated for each element of the list, with the same
+	// arguments as for Range.value, and yields the sort key. Sort keys are
+	// compared as by the lt operator. It is an error if sort keys cannot be
+	// compared. If omitted, the element value itself is the sort key.
+	Value *Value `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	// descending sorts in descending order of this key instead of ascending
+	// order.
+	Descending bool `protobuf:"varint,2,opt,name=descending,proto3" json:"descending,omitempty"`
 }
 
-func (x *Value_Map_Entry) Reset() {
-	*x = Value_Map_Entry{}
+func (x *Value_SortBy_Key) Reset() {
+	*x = Value_SortBy_Key{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_protoeval_value_proto_msgTypes[12]
+		mi := &file_protoeval_value_proto_msgTypes[22]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *Value_Map_Entry) String() string {
+func (x *Value_SortBy_Key) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Value_Map_Entry) ProtoMessage() {}
+func (*Value_SortBy_Key) ProtoMessage() {}
 
-func (x *Value_Map_Entry) ProtoReflect() protoreflect.Message {
-	mi := &file_protoeval_value_proto_msgTypes[12]
+func (x *Value_SortBy_Key) ProtoReflect() protoreflect.Message {
+	mi := &file_protoeval_value_proto_msgTypes[22]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1523,23 +2638,23 @@ func (x *Value_Map_Entry) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Value_Map_Entry.ProtoReflect.Descriptor instead.
-func (*Value_Map_Entry) Descriptor() ([]byte, []int) {
-	return file_protoeval_value_proto_rawDescGZIP(), []int{0, 3, 0}
+// Deprecated: Use Value_SortBy_Key.ProtoReflect.Descriptor instead.
+func (*Value_SortBy_Key) Descriptor() ([]byte, []int) {
+	return file_protoeval_value_proto_rawDescGZIP(), []int{0, 12, 0}
 }
 
-func (x *Value_Map_Entry) GetKey() *Value {
+func (x *Value_SortBy_Key) GetValue() *Value {
 	if x != nil {
-		return x.Key
+		return x.Value
 	}
 	return nil
 }
 
-func (x *Value_Map_Entry) GetValue() *Value {
+func (x *Value_SortBy_Key) GetDescending() bool {
 	if x != nil {
-		return x.Value
+		return x.Descending
 	}
-	return nil
+	return false
 }
 
 var File_protoeval_value_proto protoreflect.FileDescriptor
@@ -1560,7 +2675,7 @@ var file_protoeval_value_proto_rawDesc = []byte{
 	0x66, 0x2f, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1f,
 	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f,
 	0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22,
-	0xcb, 0x1a, 0x0a, 0x05, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x64, 0x72, 0x6f,
+	0xa3, 0x33, 0x0a, 0x05, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x64, 0x72, 0x6f,
 	0x70, 0x5f, 0x61, 0x72, 0x67, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x64, 0x72,
 	0x6f, 0x70, 0x41, 0x72, 0x67, 0x73, 0x12, 0x38, 0x0a, 0x04, 0x61, 0x72, 0x67, 0x73, 0x18, 0x02,
 	0x20, 0x03, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x63, 0x6f, 0x6d, 0x2e, 0x67, 0x69, 0x74, 0x68, 0x75,
@@ -1664,142 +2779,340 @@ var file_protoeval_value_proto_rawDesc = []byte{
 	0x6f, 0x6d, 0x2e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x74, 0x68, 0x65, 0x63, 0x6f, 0x75,
 	0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x65, 0x76, 0x61, 0x6c, 0x2e, 0x56, 0x61, 0x6c,
 	0x75, 0x65, 0x2e, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x48, 0x00, 0x52, 0x05, 0x72, 0x61, 0x6e, 0x67,
-	0x65, 0x1a, 0x7c, 0x0a, 0x06, 0x42, 0x72, 0x61, 0x6e, 0x63, 0x68, 0x12, 0x38, 0x0a, 0x04, 0x63,
-	0x61, 0x73, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x63, 0x6f, 0x6d, 0x2e,
-	0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x74, 0x68, 0x65, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x65, 0x76, 0x61, 0x6c, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52,
-	0x04, 0x63, 0x61, 0x73, 0x65, 0x12, 0x38, 0x0a, 0x04, 0x74, 0x68, 0x65, 0x6e, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x63, 0x6f, 0x6d, 0x2e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62,
+	0x65, 0x12, 0x42, 0x0a, 0x05, 0x70, 0x61, 0x74, 0x63, 0x68, 0x18, 0x1f, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x2a, 0x2e, 0x63, 0x6f, 0x6d, 0x2e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x74, 0x68,
+	0x65, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x65, 0x76, 0x61, 0x6c,
+	0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x2e, 0x50, 0x61, 0x74, 0x63, 0x68, 0x48, 0x00, 0x52, 0x05,
+	0x70, 0x61, 0x74, 0x63, 0x68, 0x12, 0x40, 0x0a, 0x02, 0x65, 0x71, 0x18, 0x20, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x2e, 0x2e, 0x63, 0x6f, 0x6d, 0x2e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x74,
+	0x68, 0x65, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x65, 0x76, 0x61,
+	0x6c, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x4c, 0x69, 0x73,
+	0x74, 0x48, 0x00, 0x52, 0x02, 0x65, 0x71, 0x12, 0x40, 0x0a, 0x02, 0x6e, 0x65, 0x18, 0x21, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x2e, 0x2e, 0x63, 0x6f, 0x6d, 0x2e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62,
 	0x2e, 0x74, 0x68, 0x65, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x65,
-	0x76, 0x61, 0x6c, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x04, 0x74, 0x68, 0x65, 0x6e, 0x1a,
-	0x50, 0x0a, 0x04, 0x45, 0x6e, 0x75, 0x6d, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x18, 0x0a, 0x06, 0x6e,
-	0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x48, 0x00, 0x52, 0x06, 0x6e,
-	0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x14, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20,
-	0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x42, 0x04, 0x0a, 0x02, 0x62,
-	0x79, 0x1a, 0x97, 0x01, 0x0a, 0x04, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x3d, 0x0a, 0x04, 0x6b, 0x69,
-	0x6e, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x29, 0x2e, 0x63, 0x6f, 0x6d, 0x2e, 0x67,
-	0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x74, 0x68, 0x65, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x2e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x65, 0x76, 0x61, 0x6c, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x2e, 0x4b,
-	0x69, 0x6e, 0x64, 0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70,
-	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x3c, 0x0a,
-	0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x24, 0x2e,
-	0x63, 0x6f, 0x6d, 0x2e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x74, 0x68, 0x65, 0x63, 0x6f,
-	0x75, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x65, 0x76, 0x61, 0x6c, 0x2e, 0x56, 0x61,
-	0x6c, 0x75, 0x65, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x1a, 0xfb, 0x02, 0x0a, 0x03,
-	0x4d, 0x61, 0x70, 0x12, 0x44, 0x0a, 0x08, 0x6b, 0x65, 0x79, 0x5f, 0x6b, 0x69, 0x6e, 0x64, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x29, 0x2e, 0x63, 0x6f, 0x6d, 0x2e, 0x67, 0x69, 0x74, 0x68,
+	0x76, 0x61, 0x6c, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x4c,
+	0x69, 0x73, 0x74, 0x48, 0x00, 0x52, 0x02, 0x6e, 0x65, 0x12, 0x40, 0x0a, 0x02, 0x6c, 0x74, 0x18,
+	0x22, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2e, 0x2e, 0x63, 0x6f, 0x6d, 0x2e, 0x67, 0x69, 0x74, 0x68,
 	0x75, 0x62, 0x2e, 0x74, 0x68, 0x65, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x65, 0x76, 0x61, 0x6c, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x2e, 0x4b, 0x69, 0x6e, 0x64,
-	0x52, 0x07, 0x6b, 0x65, 0x79, 0x4b, 0x69, 0x6e, 0x64, 0x12, 0x48, 0x0a, 0x0a, 0x76, 0x61, 0x6c,
-	0x75, 0x65, 0x5f, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x29, 0x2e,
+	0x6f, 0x65, 0x76, 0x61, 0x6c, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x2e, 0x56, 0x61, 0x6c, 0x75,
+	0x65, 0x4c, 0x69, 0x73, 0x74, 0x48, 0x00, 0x52, 0x02, 0x6c, 0x74, 0x12, 0x40, 0x0a, 0x02, 0x6c,
+	0x65, 0x18, 0x23, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2e, 0x2e, 0x63, 0x6f, 0x6d, 0x2e, 0x67, 0x69,
+	0x74, 0x68, 0x75, 0x62, 0x2e, 0x74, 0x68, 0x65, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x65, 0x76, 0x61, 0x6c, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x2e, 0x56, 0x61,
+	0x6c, 0x75, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x48, 0x00, 0x52, 0x02, 0x6c, 0x65, 0x12, 0x40, 0x0a,
+	0x02, 0x67, 0x74, 0x18, 0x24, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2e, 0x2e, 0x63, 0x6f, 0x6d, 0x2e,
+	0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x74, 0x68, 0x65, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x65, 0x76, 0x61, 0x6c, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x2e,
+	0x56, 0x61, 0x6c, 0x75, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x48, 0x00, 0x52, 0x02, 0x67, 0x74, 0x12,
+	0x40, 0x0a, 0x02, 0x67, 0x65, 0x18, 0x25, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2e, 0x2e, 0x63, 0x6f,
+	0x6d, 0x2e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x74, 0x68, 0x65, 0x63, 0x6f, 0x75, 0x6e,
+	0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x65, 0x76, 0x61, 0x6c, 0x2e, 0x56, 0x61, 0x6c, 0x75,
+	0x65, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x48, 0x00, 0x52, 0x02, 0x67,
+	0x65, 0x12, 0x42, 0x0a, 0x03, 0x61, 0x64, 0x64, 0x18, 0x26, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2e,
+	0x2e, 0x63, 0x6f, 0x6d, 0x2e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x74, 0x68, 0x65, 0x63,
+	0x6f, 0x75, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x65, 0x76, 0x61, 0x6c, 0x2e, 0x56,
+	0x61, 0x6c, 0x75, 0x65, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x48, 0x00,
+	0x52, 0x03, 0x61, 0x64, 0x64, 0x12, 0x42, 0x0a, 0x03, 0x73, 0x75, 0x62, 0x18, 0x27, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x2e, 0x2e, 0x63, 0x6f, 0x6d, 0x2e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e,
+	0x74, 0x68, 0x65, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x65, 0x76,
+	0x61, 0x6c, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x4c, 0x69,
+	0x73, 0x74, 0x48, 0x00, 0x52, 0x03, 0x73, 0x75, 0x62, 0x12, 0x42, 0x0a, 0x03, 0x6d, 0x75, 0x6c,
+	0x18, 0x28, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2e, 0x2e, 0x63, 0x6f, 0x6d, 0x2e, 0x67, 0x69, 0x74,
+	0x68, 0x75, 0x62, 0x2e, 0x74, 0x68, 0x65, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x65, 0x76, 0x61, 0x6c, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x2e, 0x56, 0x61, 0x6c,
+	0x75, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x48, 0x00, 0x52, 0x03, 0x6d, 0x75, 0x6c, 0x12, 0x42, 0x0a,
+	0x03, 0x64, 0x69, 0x76, 0x18, 0x29, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2e, 0x2e, 0x63, 0x6f, 0x6d,
+	0x2e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x74, 0x68, 0x65, 0x63, 0x6f, 0x75, 0x6e, 0x74,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x65, 0x76, 0x61, 0x6c, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65,
+	0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x48, 0x00, 0x52, 0x03, 0x64, 0x69,
+	0x76, 0x12, 0x42, 0x0a, 0x03, 0x6d, 0x6f, 0x64, 0x18, 0x2a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2e,
+	0x2e, 0x63, 0x6f, 0x6d, 0x2e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x74, 0x68, 0x65, 0x63,
+	0x6f, 0x75, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x65, 0x76, 0x61, 0x6c, 0x2e, 0x56,
+	0x61, 0x6c, 0x75, 0x65, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x48, 0x00,
+	0x52, 0x03, 0x6d, 0x6f, 0x64, 0x12, 0x38, 0x0a, 0x03, 0x6e, 0x65, 0x67, 0x18, 0x2b, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x24, 0x2e, 0x63, 0x6f, 0x6d, 0x2e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e,
+	0x74, 0x68, 0x65, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x65, 0x76,
+	0x61, 0x6c, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x48, 0x00, 0x52, 0x03, 0x6e, 0x65, 0x67, 0x12,
+	0x4e, 0x0a, 0x09, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x6f, 0x72, 0x6d, 0x18, 0x2c, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x2e, 0x2e, 0x63, 0x6f, 0x6d, 0x2e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e,
+	0x74, 0x68, 0x65, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x65, 0x76,
+	0x61, 0x6c, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x2e, 0x49, 0x74, 0x65, 0x72, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x48, 0x00, 0x52, 0x09, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x6f, 0x72, 0x6d, 0x12,
+	0x48, 0x0a, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x18, 0x2d, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x2e, 0x2e, 0x63, 0x6f, 0x6d, 0x2e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x74, 0x68, 0x65,
+	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x65, 0x76, 0x61, 0x6c, 0x2e,
+	0x56, 0x61, 0x6c, 0x75, 0x65, 0x2e, 0x49, 0x74, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x48,
+	0x00, 0x52, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x46, 0x0a, 0x05, 0x63, 0x6f, 0x75,
+	0x6e, 0x74, 0x18, 0x2e, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2e, 0x2e, 0x63, 0x6f, 0x6d, 0x2e, 0x67,
+	0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x74, 0x68, 0x65, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x65, 0x76, 0x61, 0x6c, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x2e, 0x49,
+	0x74, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x48, 0x00, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e,
+	0x74, 0x12, 0x42, 0x0a, 0x03, 0x73, 0x75, 0x6d, 0x18, 0x2f, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2e,
+	0x2e, 0x63, 0x6f, 0x6d, 0x2e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x74, 0x68, 0x65, 0x63,
+	0x6f, 0x75, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x65, 0x76, 0x61, 0x6c, 0x2e, 0x56,
+	0x61, 0x6c, 0x75, 0x65, 0x2e, 0x49, 0x74, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x48, 0x00,
+	0x52, 0x03, 0x73, 0x75, 0x6d, 0x12, 0x42, 0x0a, 0x03, 0x6d, 0x69, 0x6e, 0x18, 0x30, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x2e, 0x2e, 0x63, 0x6f, 0x6d, 0x2e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e,
+	0x74, 0x68, 0x65, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x65, 0x76,
+	0x61, 0x6c, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x2e, 0x49, 0x74, 0x65, 0x72, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x48, 0x00, 0x52, 0x03, 0x6d, 0x69, 0x6e, 0x12, 0x42, 0x0a, 0x03, 0x6d, 0x61, 0x78,
+	0x18, 0x31, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2e, 0x2e, 0x63, 0x6f, 0x6d, 0x2e, 0x67, 0x69, 0x74,
+	0x68, 0x75, 0x62, 0x2e, 0x74, 0x68, 0x65, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x65, 0x76, 0x61, 0x6c, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x2e, 0x49, 0x74, 0x65,
+	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x48, 0x00, 0x52, 0x03, 0x6d, 0x61, 0x78, 0x12, 0x3f, 0x0a,
+	0x04, 0x66, 0x6f, 0x6c, 0x64, 0x18, 0x32, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x29, 0x2e, 0x63, 0x6f,
+	0x6d, 0x2e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x74, 0x68, 0x65, 0x63, 0x6f, 0x75, 0x6e,
+	0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x65, 0x76, 0x61, 0x6c, 0x2e, 0x56, 0x61, 0x6c, 0x75,
+	0x65, 0x2e, 0x46, 0x6f, 0x6c, 0x64, 0x48, 0x00, 0x52, 0x04, 0x66, 0x6f, 0x6c, 0x64, 0x12, 0x46,
+	0x0a, 0x07, 0x73, 0x6f, 0x72, 0x74, 0x5f, 0x62, 0x79, 0x18, 0x33, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x2b, 0x2e, 0x63, 0x6f, 0x6d, 0x2e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x74, 0x68, 0x65,
+	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x65, 0x76, 0x61, 0x6c, 0x2e,
+	0x56, 0x61, 0x6c, 0x75, 0x65, 0x2e, 0x53, 0x6f, 0x72, 0x74, 0x42, 0x79, 0x48, 0x00, 0x52, 0x06,
+	0x73, 0x6f, 0x72, 0x74, 0x42, 0x79, 0x12, 0x49, 0x0a, 0x08, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x5f,
+	0x62, 0x79, 0x18, 0x34, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2c, 0x2e, 0x63, 0x6f, 0x6d, 0x2e, 0x67,
+	0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x74, 0x68, 0x65, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x65, 0x76, 0x61, 0x6c, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x2e, 0x47,
+	0x72, 0x6f, 0x75, 0x70, 0x42, 0x79, 0x48, 0x00, 0x52, 0x07, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x42,
+	0x79, 0x12, 0x3c, 0x0a, 0x03, 0x74, 0x72, 0x79, 0x18, 0x35, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x28,
+	0x2e, 0x63, 0x6f, 0x6d, 0x2e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x74, 0x68, 0x65, 0x63,
+	0x6f, 0x75, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x65, 0x76, 0x61, 0x6c, 0x2e, 0x56,
+	0x61, 0x6c, 0x75, 0x65, 0x2e, 0x54, 0x72, 0x79, 0x48, 0x00, 0x52, 0x03, 0x74, 0x72, 0x79, 0x12,
+	0x3f, 0x0a, 0x04, 0x66, 0x61, 0x69, 0x6c, 0x18, 0x36, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x29, 0x2e,
 	0x63, 0x6f, 0x6d, 0x2e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x74, 0x68, 0x65, 0x63, 0x6f,
 	0x75, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x65, 0x76, 0x61, 0x6c, 0x2e, 0x56, 0x61,
-	0x6c, 0x75, 0x65, 0x2e, 0x4b, 0x69, 0x6e, 0x64, 0x52, 0x09, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x4b,
-	0x69, 0x6e, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x5f, 0x74, 0x79, 0x70,
-	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x54, 0x79,
-	0x70, 0x65, 0x12, 0x48, 0x0a, 0x07, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x18, 0x04, 0x20,
-	0x03, 0x28, 0x0b, 0x32, 0x2e, 0x2e, 0x63, 0x6f, 0x6d, 0x2e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62,
-	0x2e, 0x74, 0x68, 0x65, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x65,
-	0x76, 0x61, 0x6c, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x2e, 0x4d, 0x61, 0x70, 0x2e, 0x45, 0x6e,
-	0x74, 0x72, 0x79, 0x52, 0x07, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x1a, 0x7b, 0x0a, 0x05,
-	0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x36, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01,
+	0x6c, 0x75, 0x65, 0x2e, 0x46, 0x61, 0x69, 0x6c, 0x48, 0x00, 0x52, 0x04, 0x66, 0x61, 0x69, 0x6c,
+	0x1a, 0x7c, 0x0a, 0x06, 0x42, 0x72, 0x61, 0x6e, 0x63, 0x68, 0x12, 0x38, 0x0a, 0x04, 0x63, 0x61,
+	0x73, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x63, 0x6f, 0x6d, 0x2e, 0x67,
+	0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x74, 0x68, 0x65, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x65, 0x76, 0x61, 0x6c, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x04,
+	0x63, 0x61, 0x73, 0x65, 0x12, 0x38, 0x0a, 0x04, 0x74, 0x68, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01,
 	0x28, 0x0b, 0x32, 0x24, 0x2e, 0x63, 0x6f, 0x6d, 0x2e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e,
 	0x74, 0x68, 0x65, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x65, 0x76,
-	0x61, 0x6c, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x3a, 0x0a,
-	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x63,
+	0x61, 0x6c, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x04, 0x74, 0x68, 0x65, 0x6e, 0x1a, 0x50,
+	0x0a, 0x04, 0x45, 0x6e, 0x75, 0x6d, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x18, 0x0a, 0x06, 0x6e, 0x75,
+	0x6d, 0x62, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x48, 0x00, 0x52, 0x06, 0x6e, 0x75,
+	0x6d, 0x62, 0x65, 0x72, 0x12, 0x14, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x48, 0x00, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x42, 0x04, 0x0a, 0x02, 0x62, 0x79,
+	0x1a, 0x97, 0x01, 0x0a, 0x04, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x3d, 0x0a, 0x04, 0x6b, 0x69, 0x6e,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x29, 0x2e, 0x63, 0x6f, 0x6d, 0x2e, 0x67, 0x69,
+	0x74, 0x68, 0x75, 0x62, 0x2e, 0x74, 0x68, 0x65, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x65, 0x76, 0x61, 0x6c, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x2e, 0x4b, 0x69,
+	0x6e, 0x64, 0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x3c, 0x0a, 0x06,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x63,
 	0x6f, 0x6d, 0x2e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x74, 0x68, 0x65, 0x63, 0x6f, 0x75,
 	0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x65, 0x76, 0x61, 0x6c, 0x2e, 0x56, 0x61, 0x6c,
-	0x75, 0x65, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x1a, 0xd0, 0x01, 0x0a, 0x07, 0x4d, 0x65,
-	0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x50, 0x0a, 0x06, 0x66, 0x69, 0x65,
-	0x6c, 0x64, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x38, 0x2e, 0x63, 0x6f, 0x6d, 0x2e,
-	0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x74, 0x68, 0x65, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x65, 0x76, 0x61, 0x6c, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x2e,
-	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x45, 0x6e,
-	0x74, 0x72, 0x79, 0x52, 0x06, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x1a, 0x5f, 0x0a, 0x0b, 0x46,
-	0x69, 0x65, 0x6c, 0x64, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65,
-	0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x3a, 0x0a, 0x05,
+	0x75, 0x65, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x1a, 0xfb, 0x02, 0x0a, 0x03, 0x4d,
+	0x61, 0x70, 0x12, 0x44, 0x0a, 0x08, 0x6b, 0x65, 0x79, 0x5f, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x29, 0x2e, 0x63, 0x6f, 0x6d, 0x2e, 0x67, 0x69, 0x74, 0x68, 0x75,
+	0x62, 0x2e, 0x74, 0x68, 0x65, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x65, 0x76, 0x61, 0x6c, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x2e, 0x4b, 0x69, 0x6e, 0x64, 0x52,
+	0x07, 0x6b, 0x65, 0x79, 0x4b, 0x69, 0x6e, 0x64, 0x12, 0x48, 0x0a, 0x0a, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x5f, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x29, 0x2e, 0x63,
+	0x6f, 0x6d, 0x2e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x74, 0x68, 0x65, 0x63, 0x6f, 0x75,
+	0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x65, 0x76, 0x61, 0x6c, 0x2e, 0x56, 0x61, 0x6c,
+	0x75, 0x65, 0x2e, 0x4b, 0x69, 0x6e, 0x64, 0x52, 0x09, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x4b, 0x69,
+	0x6e, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x5f, 0x74, 0x79, 0x70, 0x65,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x54, 0x79, 0x70,
+	0x65, 0x12, 0x48, 0x0a, 0x07, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x2e, 0x2e, 0x63, 0x6f, 0x6d, 0x2e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e,
+	0x74, 0x68, 0x65, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x65, 0x76,
+	0x61, 0x6c, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x2e, 0x4d, 0x61, 0x70, 0x2e, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x52, 0x07, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x1a, 0x7b, 0x0a, 0x05, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x12, 0x36, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x24, 0x2e, 0x63, 0x6f, 0x6d, 0x2e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x74,
+	0x68, 0x65, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x65, 0x76, 0x61,
+	0x6c, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x3a, 0x0a, 0x05,
 	0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x63, 0x6f,
 	0x6d, 0x2e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x74, 0x68, 0x65, 0x63, 0x6f, 0x75, 0x6e,
 	0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x65, 0x76, 0x61, 0x6c, 0x2e, 0x56, 0x61, 0x6c, 0x75,
-	0x65, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x33, 0x0a, 0x07,
-	0x50, 0x72, 0x6f, 0x67, 0x72, 0x61, 0x6d, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x6c,
-	0x69, 0x6e, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x6c, 0x69, 0x6e, 0x65,
-	0x73, 0x1a, 0x85, 0x01, 0x0a, 0x05, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x40, 0x0a, 0x08, 0x69,
-	0x74, 0x65, 0x72, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e,
-	0x63, 0x6f, 0x6d, 0x2e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x74, 0x68, 0x65, 0x63, 0x6f,
-	0x75, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x65, 0x76, 0x61, 0x6c, 0x2e, 0x56, 0x61,
-	0x6c, 0x75, 0x65, 0x52, 0x08, 0x69, 0x74, 0x65, 0x72, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x3a, 0x0a,
-	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x63,
+	0x65, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x1a, 0xbc, 0x02, 0x0a, 0x07, 0x4d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x50, 0x0a, 0x06, 0x66, 0x69, 0x65, 0x6c,
+	0x64, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x38, 0x2e, 0x63, 0x6f, 0x6d, 0x2e, 0x67,
+	0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x74, 0x68, 0x65, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x65, 0x76, 0x61, 0x6c, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x2e, 0x4d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x52, 0x06, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x12, 0x30, 0x0a, 0x05, 0x63, 0x6c,
+	0x65, 0x61, 0x72, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x4c, 0x69, 0x73, 0x74,
+	0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x05, 0x63, 0x6c, 0x65, 0x61, 0x72, 0x12, 0x38, 0x0a, 0x04,
+	0x62, 0x61, 0x73, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x63, 0x6f, 0x6d,
+	0x2e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x74, 0x68, 0x65, 0x63, 0x6f, 0x75, 0x6e, 0x74,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x65, 0x76, 0x61, 0x6c, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65,
+	0x52, 0x04, 0x62, 0x61, 0x73, 0x65, 0x1a, 0x5f, 0x0a, 0x0b, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x73,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x3a, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x63, 0x6f, 0x6d, 0x2e, 0x67, 0x69, 0x74,
+	0x68, 0x75, 0x62, 0x2e, 0x74, 0x68, 0x65, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x65, 0x76, 0x61, 0x6c, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x85, 0x03, 0x0a, 0x05, 0x50, 0x61, 0x74, 0x63,
+	0x68, 0x12, 0x54, 0x0a, 0x0a, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x34, 0x2e, 0x63, 0x6f, 0x6d, 0x2e, 0x67, 0x69, 0x74, 0x68,
+	0x75, 0x62, 0x2e, 0x74, 0x68, 0x65, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x65, 0x76, 0x61, 0x6c, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x2e, 0x50, 0x61, 0x74, 0x63,
+	0x68, 0x2e, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0a, 0x6f, 0x70, 0x65,
+	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x1a, 0xa5, 0x02, 0x0a, 0x09, 0x4f, 0x70, 0x65, 0x72,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x2e, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52,
+	0x04, 0x70, 0x61, 0x74, 0x68, 0x12, 0x38, 0x0a, 0x04, 0x77, 0x68, 0x65, 0x6e, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x63, 0x6f, 0x6d, 0x2e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62,
+	0x2e, 0x74, 0x68, 0x65, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x65,
+	0x76, 0x61, 0x6c, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x04, 0x77, 0x68, 0x65, 0x6e, 0x12,
+	0x38, 0x0a, 0x03, 0x73, 0x65, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x63,
 	0x6f, 0x6d, 0x2e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x74, 0x68, 0x65, 0x63, 0x6f, 0x75,
 	0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x65, 0x76, 0x61, 0x6c, 0x2e, 0x56, 0x61, 0x6c,
-	0x75, 0x65, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x1a, 0x81, 0x01, 0x0a, 0x0b, 0x53, 0x74,
-	0x6f, 0x72, 0x65, 0x64, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x36, 0x0a, 0x03, 0x6b, 0x65, 0x79,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x63, 0x6f, 0x6d, 0x2e, 0x67, 0x69, 0x74,
-	0x68, 0x75, 0x62, 0x2e, 0x74, 0x68, 0x65, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x65, 0x76, 0x61, 0x6c, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x03, 0x6b, 0x65,
-	0x79, 0x12, 0x3a, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
+	0x75, 0x65, 0x48, 0x00, 0x52, 0x03, 0x73, 0x65, 0x74, 0x12, 0x2e, 0x0a, 0x05, 0x63, 0x6c, 0x65,
+	0x61, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79,
+	0x48, 0x00, 0x52, 0x05, 0x63, 0x6c, 0x65, 0x61, 0x72, 0x12, 0x3e, 0x0a, 0x06, 0x61, 0x70, 0x70,
+	0x65, 0x6e, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x63, 0x6f, 0x6d, 0x2e,
+	0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x74, 0x68, 0x65, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x65, 0x76, 0x61, 0x6c, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x48,
+	0x00, 0x52, 0x06, 0x61, 0x70, 0x70, 0x65, 0x6e, 0x64, 0x42, 0x04, 0x0a, 0x02, 0x6f, 0x70, 0x1a,
+	0x33, 0x0a, 0x07, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x61, 0x6d, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x6f,
+	0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x12, 0x14,
+	0x0a, 0x05, 0x6c, 0x69, 0x6e, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x6c,
+	0x69, 0x6e, 0x65, 0x73, 0x1a, 0xa6, 0x01, 0x0a, 0x05, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x40,
+	0x0a, 0x08, 0x69, 0x74, 0x65, 0x72, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x24, 0x2e, 0x63, 0x6f, 0x6d, 0x2e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x74, 0x68,
+	0x65, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x65, 0x76, 0x61, 0x6c,
+	0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x08, 0x69, 0x74, 0x65, 0x72, 0x61, 0x62, 0x6c, 0x65,
+	0x12, 0x3a, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x24, 0x2e, 0x63, 0x6f, 0x6d, 0x2e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x74, 0x68, 0x65,
+	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x65, 0x76, 0x61, 0x6c, 0x2e,
+	0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x1f, 0x0a, 0x0b,
+	0x73, 0x6f, 0x72, 0x74, 0x65, 0x64, 0x5f, 0x6b, 0x65, 0x79, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x0a, 0x73, 0x6f, 0x72, 0x74, 0x65, 0x64, 0x4b, 0x65, 0x79, 0x73, 0x1a, 0x81, 0x01,
+	0x0a, 0x0b, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x64, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x36, 0x0a,
+	0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x63, 0x6f, 0x6d,
+	0x2e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x74, 0x68, 0x65, 0x63, 0x6f, 0x75, 0x6e, 0x74,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x65, 0x76, 0x61, 0x6c, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65,
+	0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x3a, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x63, 0x6f, 0x6d, 0x2e, 0x67, 0x69, 0x74, 0x68, 0x75,
+	0x62, 0x2e, 0x74, 0x68, 0x65, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x65, 0x76, 0x61, 0x6c, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x1a, 0x8b, 0x01, 0x0a, 0x06, 0x53, 0x77, 0x69, 0x74, 0x63, 0x68, 0x12, 0x41, 0x0a, 0x05,
+	0x63, 0x61, 0x73, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2b, 0x2e, 0x63, 0x6f,
+	0x6d, 0x2e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x74, 0x68, 0x65, 0x63, 0x6f, 0x75, 0x6e,
+	0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x65, 0x76, 0x61, 0x6c, 0x2e, 0x56, 0x61, 0x6c, 0x75,
+	0x65, 0x2e, 0x42, 0x72, 0x61, 0x6e, 0x63, 0x68, 0x52, 0x05, 0x63, 0x61, 0x73, 0x65, 0x73, 0x12,
+	0x3e, 0x0a, 0x07, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
 	0x32, 0x24, 0x2e, 0x63, 0x6f, 0x6d, 0x2e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x74, 0x68,
 	0x65, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x65, 0x76, 0x61, 0x6c,
-	0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x1a, 0x8b, 0x01,
-	0x0a, 0x06, 0x53, 0x77, 0x69, 0x74, 0x63, 0x68, 0x12, 0x41, 0x0a, 0x05, 0x63, 0x61, 0x73, 0x65,
-	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2b, 0x2e, 0x63, 0x6f, 0x6d, 0x2e, 0x67, 0x69,
+	0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x07, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x1a,
+	0x89, 0x01, 0x0a, 0x09, 0x49, 0x74, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x40, 0x0a,
+	0x08, 0x69, 0x74, 0x65, 0x72, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x24, 0x2e, 0x63, 0x6f, 0x6d, 0x2e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x74, 0x68, 0x65,
+	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x65, 0x76, 0x61, 0x6c, 0x2e,
+	0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x08, 0x69, 0x74, 0x65, 0x72, 0x61, 0x62, 0x6c, 0x65, 0x12,
+	0x3a, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x24,
+	0x2e, 0x63, 0x6f, 0x6d, 0x2e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x74, 0x68, 0x65, 0x63,
+	0x6f, 0x75, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x65, 0x76, 0x61, 0x6c, 0x2e, 0x56,
+	0x61, 0x6c, 0x75, 0x65, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x1a, 0xbe, 0x01, 0x0a, 0x04,
+	0x46, 0x6f, 0x6c, 0x64, 0x12, 0x40, 0x0a, 0x08, 0x69, 0x74, 0x65, 0x72, 0x61, 0x62, 0x6c, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x63, 0x6f, 0x6d, 0x2e, 0x67, 0x69, 0x74,
+	0x68, 0x75, 0x62, 0x2e, 0x74, 0x68, 0x65, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x65, 0x76, 0x61, 0x6c, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x08, 0x69, 0x74,
+	0x65, 0x72, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x38, 0x0a, 0x04, 0x69, 0x6e, 0x69, 0x74, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x63, 0x6f, 0x6d, 0x2e, 0x67, 0x69, 0x74, 0x68, 0x75,
+	0x62, 0x2e, 0x74, 0x68, 0x65, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x65, 0x76, 0x61, 0x6c, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x04, 0x69, 0x6e, 0x69, 0x74,
+	0x12, 0x3a, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x24, 0x2e, 0x63, 0x6f, 0x6d, 0x2e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x74, 0x68, 0x65,
+	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x65, 0x76, 0x61, 0x6c, 0x2e,
+	0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x1a, 0xf2, 0x01, 0x0a,
+	0x06, 0x53, 0x6f, 0x72, 0x74, 0x42, 0x79, 0x12, 0x40, 0x0a, 0x08, 0x69, 0x74, 0x65, 0x72, 0x61,
+	0x62, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x63, 0x6f, 0x6d, 0x2e,
+	0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x74, 0x68, 0x65, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x65, 0x76, 0x61, 0x6c, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52,
+	0x08, 0x69, 0x74, 0x65, 0x72, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x43, 0x0a, 0x04, 0x6b, 0x65, 0x79,
+	0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2f, 0x2e, 0x63, 0x6f, 0x6d, 0x2e, 0x67, 0x69,
 	0x74, 0x68, 0x75, 0x62, 0x2e, 0x74, 0x68, 0x65, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x65, 0x76, 0x61, 0x6c, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x2e, 0x42, 0x72,
-	0x61, 0x6e, 0x63, 0x68, 0x52, 0x05, 0x63, 0x61, 0x73, 0x65, 0x73, 0x12, 0x3e, 0x0a, 0x07, 0x64,
-	0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x63,
+	0x6f, 0x74, 0x6f, 0x65, 0x76, 0x61, 0x6c, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x2e, 0x53, 0x6f,
+	0x72, 0x74, 0x42, 0x79, 0x2e, 0x4b, 0x65, 0x79, 0x52, 0x04, 0x6b, 0x65, 0x79, 0x73, 0x1a, 0x61,
+	0x0a, 0x03, 0x4b, 0x65, 0x79, 0x12, 0x3a, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x63, 0x6f, 0x6d, 0x2e, 0x67, 0x69, 0x74, 0x68, 0x75,
+	0x62, 0x2e, 0x74, 0x68, 0x65, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x65, 0x76, 0x61, 0x6c, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x64, 0x65, 0x73, 0x63, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x64, 0x65, 0x73, 0x63, 0x65, 0x6e, 0x64, 0x69, 0x6e,
+	0x67, 0x1a, 0xbf, 0x01, 0x0a, 0x07, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x42, 0x79, 0x12, 0x40, 0x0a,
+	0x08, 0x69, 0x74, 0x65, 0x72, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x24, 0x2e, 0x63, 0x6f, 0x6d, 0x2e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x74, 0x68, 0x65,
+	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x65, 0x76, 0x61, 0x6c, 0x2e,
+	0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x08, 0x69, 0x74, 0x65, 0x72, 0x61, 0x62, 0x6c, 0x65, 0x12,
+	0x36, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x63,
 	0x6f, 0x6d, 0x2e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x74, 0x68, 0x65, 0x63, 0x6f, 0x75,
 	0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x65, 0x76, 0x61, 0x6c, 0x2e, 0x56, 0x61, 0x6c,
-	0x75, 0x65, 0x52, 0x07, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x1a, 0x49, 0x0a, 0x09, 0x56,
-	0x61, 0x6c, 0x75, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x3c, 0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75,
-	0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x63, 0x6f, 0x6d, 0x2e, 0x67,
-	0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x74, 0x68, 0x65, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x2e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x65, 0x76, 0x61, 0x6c, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x06,
-	0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x22, 0xeb, 0x01, 0x0a, 0x04, 0x4b, 0x69, 0x6e, 0x64, 0x12,
-	0x0b, 0x0a, 0x07, 0x49, 0x4e, 0x56, 0x41, 0x4c, 0x49, 0x44, 0x10, 0x00, 0x12, 0x0a, 0x0a, 0x06,
-	0x44, 0x4f, 0x55, 0x42, 0x4c, 0x45, 0x10, 0x01, 0x12, 0x09, 0x0a, 0x05, 0x46, 0x4c, 0x4f, 0x41,
-	0x54, 0x10, 0x02, 0x12, 0x09, 0x0a, 0x05, 0x49, 0x4e, 0x54, 0x36, 0x34, 0x10, 0x03, 0x12, 0x0a,
-	0x0a, 0x06, 0x55, 0x49, 0x4e, 0x54, 0x36, 0x34, 0x10, 0x04, 0x12, 0x09, 0x0a, 0x05, 0x49, 0x4e,
-	0x54, 0x33, 0x32, 0x10, 0x05, 0x12, 0x0b, 0x0a, 0x07, 0x46, 0x49, 0x58, 0x45, 0x44, 0x36, 0x34,
-	0x10, 0x06, 0x12, 0x0b, 0x0a, 0x07, 0x46, 0x49, 0x58, 0x45, 0x44, 0x33, 0x32, 0x10, 0x07, 0x12,
-	0x08, 0x0a, 0x04, 0x42, 0x4f, 0x4f, 0x4c, 0x10, 0x08, 0x12, 0x0a, 0x0a, 0x06, 0x53, 0x54, 0x52,
-	0x49, 0x4e, 0x47, 0x10, 0x09, 0x12, 0x0b, 0x0a, 0x07, 0x4d, 0x45, 0x53, 0x53, 0x41, 0x47, 0x45,
-	0x10, 0x0b, 0x12, 0x09, 0x0a, 0x05, 0x42, 0x59, 0x54, 0x45, 0x53, 0x10, 0x0c, 0x12, 0x0a, 0x0a,
-	0x06, 0x55, 0x49, 0x4e, 0x54, 0x33, 0x32, 0x10, 0x0d, 0x12, 0x08, 0x0a, 0x04, 0x45, 0x4e, 0x55,
-	0x4d, 0x10, 0x0e, 0x12, 0x0c, 0x0a, 0x08, 0x53, 0x46, 0x49, 0x58, 0x45, 0x44, 0x33, 0x32, 0x10,
-	0x0f, 0x12, 0x0c, 0x0a, 0x08, 0x53, 0x46, 0x49, 0x58, 0x45, 0x44, 0x36, 0x34, 0x10, 0x10, 0x12,
-	0x0a, 0x0a, 0x06, 0x53, 0x49, 0x4e, 0x54, 0x33, 0x32, 0x10, 0x11, 0x12, 0x0a, 0x0a, 0x06, 0x53,
-	0x49, 0x4e, 0x54, 0x36, 0x34, 0x10, 0x12, 0x22, 0x04, 0x08, 0x0a, 0x10, 0x0a, 0x2a, 0x05, 0x47,
-	0x52, 0x4f, 0x55, 0x50, 0x42, 0x07, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0xfc, 0x02,
-	0x0a, 0x05, 0x53, 0x63, 0x6f, 0x70, 0x65, 0x12, 0x3c, 0x0a, 0x06, 0x70, 0x61, 0x72, 0x65, 0x6e,
-	0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x63, 0x6f, 0x6d, 0x2e, 0x67, 0x69,
+	0x75, 0x65, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x3a, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x63, 0x6f, 0x6d, 0x2e, 0x67, 0x69, 0x74,
+	0x68, 0x75, 0x62, 0x2e, 0x74, 0x68, 0x65, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x65, 0x76, 0x61, 0x6c, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x1a, 0x81, 0x01, 0x0a, 0x03, 0x54, 0x72, 0x79, 0x12, 0x38, 0x0a, 0x04, 0x62,
+	0x6f, 0x64, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x63, 0x6f, 0x6d, 0x2e,
+	0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x74, 0x68, 0x65, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x65, 0x76, 0x61, 0x6c, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52,
+	0x04, 0x62, 0x6f, 0x64, 0x79, 0x12, 0x40, 0x0a, 0x08, 0x66, 0x61, 0x6c, 0x6c, 0x62, 0x61, 0x63,
+	0x6b, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x63, 0x6f, 0x6d, 0x2e, 0x67, 0x69,
 	0x74, 0x68, 0x75, 0x62, 0x2e, 0x74, 0x68, 0x65, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x65, 0x76, 0x61, 0x6c, 0x2e, 0x53, 0x63, 0x6f, 0x70, 0x65, 0x52, 0x06, 0x70,
-	0x61, 0x72, 0x65, 0x6e, 0x74, 0x12, 0x50, 0x0a, 0x10, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x5f, 0x64,
-	0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x25, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
-	0x66, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x44, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x6f,
-	0x72, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x52, 0x0f, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x44, 0x65, 0x73,
-	0x63, 0x72, 0x69, 0x70, 0x74, 0x6f, 0x72, 0x12, 0x2a, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
-	0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x41, 0x6e, 0x79, 0x52, 0x05, 0x76, 0x61,
-	0x6c, 0x75, 0x65, 0x12, 0x28, 0x0a, 0x04, 0x6c, 0x69, 0x73, 0x74, 0x18, 0x04, 0x20, 0x03, 0x28,
-	0x0b, 0x32, 0x14, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x62, 0x75, 0x66, 0x2e, 0x41, 0x6e, 0x79, 0x52, 0x04, 0x6c, 0x69, 0x73, 0x74, 0x12, 0x3f, 0x0a,
-	0x03, 0x6d, 0x61, 0x70, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2d, 0x2e, 0x63, 0x6f, 0x6d,
-	0x2e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x74, 0x68, 0x65, 0x63, 0x6f, 0x75, 0x6e, 0x74,
-	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x65, 0x76, 0x61, 0x6c, 0x2e, 0x53, 0x63, 0x6f, 0x70, 0x65,
-	0x2e, 0x4d, 0x61, 0x70, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x03, 0x6d, 0x61, 0x70, 0x1a, 0x4c,
-	0x0a, 0x08, 0x4d, 0x61, 0x70, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65,
-	0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x2a, 0x0a, 0x05,
-	0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x67, 0x6f,
-	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x41, 0x6e,
-	0x79, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x42, 0x1f, 0x5a, 0x1d,
-	0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x54, 0x68, 0x65, 0x43, 0x6f,
-	0x75, 0x6e, 0x74, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x65, 0x76, 0x61, 0x6c, 0x62, 0x06, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x6f, 0x74, 0x6f, 0x65, 0x76, 0x61, 0x6c, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x08, 0x66,
+	0x61, 0x6c, 0x6c, 0x62, 0x61, 0x63, 0x6b, 0x1a, 0x5a, 0x0a, 0x04, 0x46, 0x61, 0x69, 0x6c, 0x12,
+	0x12, 0x0a, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x63,
+	0x6f, 0x64, 0x65, 0x12, 0x3e, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x63, 0x6f, 0x6d, 0x2e, 0x67, 0x69, 0x74, 0x68, 0x75,
+	0x62, 0x2e, 0x74, 0x68, 0x65, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x65, 0x76, 0x61, 0x6c, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x1a, 0x49, 0x0a, 0x09, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x4c, 0x69, 0x73, 0x74,
+	0x12, 0x3c, 0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x24, 0x2e, 0x63, 0x6f, 0x6d, 0x2e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x74, 0x68,
+	0x65, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x65, 0x76, 0x61, 0x6c,
+	0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x22, 0xeb,
+	0x01, 0x0a, 0x04, 0x4b, 0x69, 0x6e, 0x64, 0x12, 0x0b, 0x0a, 0x07, 0x49, 0x4e, 0x56, 0x41, 0x4c,
+	0x49, 0x44, 0x10, 0x00, 0x12, 0x0a, 0x0a, 0x06, 0x44, 0x4f, 0x55, 0x42, 0x4c, 0x45, 0x10, 0x01,
+	0x12, 0x09, 0x0a, 0x05, 0x46, 0x4c, 0x4f, 0x41, 0x54, 0x10, 0x02, 0x12, 0x09, 0x0a, 0x05, 0x49,
+	0x4e, 0x54, 0x36, 0x34, 0x10, 0x03, 0x12, 0x0a, 0x0a, 0x06, 0x55, 0x49, 0x4e, 0x54, 0x36, 0x34,
+	0x10, 0x04, 0x12, 0x09, 0x0a, 0x05, 0x49, 0x4e, 0x54, 0x33, 0x32, 0x10, 0x05, 0x12, 0x0b, 0x0a,
+	0x07, 0x46, 0x49, 0x58, 0x45, 0x44, 0x36, 0x34, 0x10, 0x06, 0x12, 0x0b, 0x0a, 0x07, 0x46, 0x49,
+	0x58, 0x45, 0x44, 0x33, 0x32, 0x10, 0x07, 0x12, 0x08, 0x0a, 0x04, 0x42, 0x4f, 0x4f, 0x4c, 0x10,
+	0x08, 0x12, 0x0a, 0x0a, 0x06, 0x53, 0x54, 0x52, 0x49, 0x4e, 0x47, 0x10, 0x09, 0x12, 0x0b, 0x0a,
+	0x07, 0x4d, 0x45, 0x53, 0x53, 0x41, 0x47, 0x45, 0x10, 0x0b, 0x12, 0x09, 0x0a, 0x05, 0x42, 0x59,
+	0x54, 0x45, 0x53, 0x10, 0x0c, 0x12, 0x0a, 0x0a, 0x06, 0x55, 0x49, 0x4e, 0x54, 0x33, 0x32, 0x10,
+	0x0d, 0x12, 0x08, 0x0a, 0x04, 0x45, 0x4e, 0x55, 0x4d, 0x10, 0x0e, 0x12, 0x0c, 0x0a, 0x08, 0x53,
+	0x46, 0x49, 0x58, 0x45, 0x44, 0x33, 0x32, 0x10, 0x0f, 0x12, 0x0c, 0x0a, 0x08, 0x53, 0x46, 0x49,
+	0x58, 0x45, 0x44, 0x36, 0x34, 0x10, 0x10, 0x12, 0x0a, 0x0a, 0x06, 0x53, 0x49, 0x4e, 0x54, 0x33,
+	0x32, 0x10, 0x11, 0x12, 0x0a, 0x0a, 0x06, 0x53, 0x49, 0x4e, 0x54, 0x36, 0x34, 0x10, 0x12, 0x22,
+	0x04, 0x08, 0x0a, 0x10, 0x0a, 0x2a, 0x05, 0x47, 0x52, 0x4f, 0x55, 0x50, 0x42, 0x07, 0x0a, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x80, 0x03, 0x0a, 0x05, 0x53, 0x63, 0x6f, 0x70, 0x65, 0x12,
+	0x3c, 0x0a, 0x06, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x24, 0x2e, 0x63, 0x6f, 0x6d, 0x2e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x74, 0x68, 0x65,
+	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x65, 0x76, 0x61, 0x6c, 0x2e,
+	0x53, 0x63, 0x6f, 0x70, 0x65, 0x52, 0x06, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x12, 0x50, 0x0a,
+	0x10, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x5f, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x6f,
+	0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x25, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x44,
+	0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x6f, 0x72, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x52, 0x0f,
+	0x66, 0x69, 0x65, 0x6c, 0x64, 0x44, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x6f, 0x72, 0x12,
+	0x2a, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x41, 0x6e, 0x79, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x28, 0x0a, 0x04, 0x6c,
+	0x69, 0x73, 0x74, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x41, 0x6e, 0x79, 0x52,
+	0x04, 0x6c, 0x69, 0x73, 0x74, 0x12, 0x3f, 0x0a, 0x03, 0x6d, 0x61, 0x70, 0x18, 0x05, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x2d, 0x2e, 0x63, 0x6f, 0x6d, 0x2e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e,
+	0x74, 0x68, 0x65, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x65, 0x76,
+	0x61, 0x6c, 0x2e, 0x53, 0x63, 0x6f, 0x70, 0x65, 0x2e, 0x4d, 0x61, 0x70, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x52, 0x03, 0x6d, 0x61, 0x70, 0x1a, 0x4c, 0x0a, 0x08, 0x4d, 0x61, 0x70, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x03, 0x6b, 0x65, 0x79, 0x12, 0x2a, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x41, 0x6e, 0x79, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x3a, 0x02, 0x38, 0x01, 0x3a, 0x02, 0x18, 0x01, 0x42, 0x1f, 0x5a, 0x1d, 0x67, 0x69, 0x74, 0x68,
+	0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x54, 0x68, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x2f,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x65, 0x76, 0x61, 0x6c, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x33,
 }
 
 var (
@@ -1815,7 +3128,7 @@ func file_protoeval_value_proto_rawDescGZIP() []byte {
 }
 
 var file_protoeval_value_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_protoeval_value_proto_msgTypes = make([]protoimpl.MessageInfo, 15)
+var file_protoeval_value_proto_msgTypes = make([]protoimpl.MessageInfo, 24)
 var file_protoeval_value_proto_goTypes = []interface{}{
 	(Value_Kind)(0),                           // 0: com.github.thecount.protoeval.Value.Kind
 	(*Value)(nil),                             // 1: com.github.thecount.protoeval.Value
@@ -1825,75 +3138,130 @@ var file_protoeval_value_proto_goTypes = []interface{}{
 	(*Value_List)(nil),                        // 5: com.github.thecount.protoeval.Value.List
 	(*Value_Map)(nil),                         // 6: com.github.thecount.protoeval.Value.Map
 	(*Value_Message)(nil),                     // 7: com.github.thecount.protoeval.Value.Message
-	(*Value_Program)(nil),                     // 8: com.github.thecount.protoeval.Value.Program
-	(*Value_Range)(nil),                       // 9: com.github.thecount.protoeval.Value.Range
-	(*Value_StoredValue)(nil),                 // 10: com.github.thecount.protoeval.Value.StoredValue
-	(*Value_Switch)(nil),                      // 11: com.github.thecount.protoeval.Value.Switch
-	(*Value_ValueList)(nil),                   // 12: com.github.thecount.protoeval.Value.ValueList
-	(*Value_Map_Entry)(nil),                   // 13: com.github.thecount.protoeval.Value.Map.Entry
-	nil,                                       // 14: com.github.thecount.protoeval.Value.Message.FieldsEntry
-	nil,                                       // 15: com.github.thecount.protoeval.Scope.MapEntry
-	(*structpb.ListValue)(nil),                // 16: google.protobuf.ListValue
-	(*emptypb.Empty)(nil),                     // 17: google.protobuf.Empty
-	(*structpb.Value)(nil),                    // 18: google.protobuf.Value
-	(*anypb.Any)(nil),                         // 19: google.protobuf.Any
-	(*durationpb.Duration)(nil),               // 20: google.protobuf.Duration
-	(*timestamppb.Timestamp)(nil),             // 21: google.protobuf.Timestamp
-	(*descriptorpb.FieldDescriptorProto)(nil), // 22: google.protobuf.FieldDescriptorProto
+	(*Value_Patch)(nil),                       // 8: com.github.thecount.protoeval.Value.Patch
+	(*Value_Program)(nil),                     // 9: com.github.thecount.protoeval.Value.Program
+	(*Value_Range)(nil),                       // 10: com.github.thecount.protoeval.Value.Range
+	(*Value_StoredValue)(nil),                 // 11: com.github.thecount.protoeval.Value.StoredValue
+	(*Value_Switch)(nil),                      // 12: com.github.thecount.protoeval.Value.Switch
+	(*Value_Iteration)(nil),                   // 13: com.github.thecount.protoeval.Value.Iteration
+	(*Value_Fold)(nil),                        // 14: com.github.thecount.protoeval.Value.Fold
+	(*Value_SortBy)(nil),                      // 15: com.github.thecount.protoeval.Value.SortBy
+	(*Value_GroupBy)(nil),                     // 16: com.github.thecount.protoeval.Value.GroupBy
+	(*Value_Try)(nil),                         // 17: com.github.thecount.protoeval.Value.Try
+	(*Value_Fail)(nil),                        // 18: com.github.thecount.protoeval.Value.Fail
+	(*Value_ValueList)(nil),                   // 19: com.github.thecount.protoeval.Value.ValueList
+	(*Value_Map_Entry)(nil),                   // 20: com.github.thecount.protoeval.Value.Map.Entry
+	nil,                                       // 21: com.github.thecount.protoeval.Value.Message.FieldsEntry
+	(*Value_Patch_Operation)(nil),             // 22: com.github.thecount.protoeval.Value.Patch.Operation
+	(*Value_SortBy_Key)(nil),                  // 23: com.github.thecount.protoeval.Value.SortBy.Key
+	nil,                                       // 24: com.github.thecount.protoeval.Scope.MapEntry
+	(*structpb.ListValue)(nil),                // 25: google.protobuf.ListValue
+	(*emptypb.Empty)(nil),                     // 26: google.protobuf.Empty
+	(*structpb.Value)(nil),                    // 27: google.protobuf.Value
+	(*anypb.Any)(nil),                         // 28: google.protobuf.Any
+	(*durationpb.Duration)(nil),               // 29: google.protobuf.Duration
+	(*timestamppb.Timestamp)(nil),             // 30: google.protobuf.Timestamp
+	(*descriptorpb.FieldDescriptorProto)(nil), // 31: google.protobuf.FieldDescriptorProto
 }
 var file_protoeval_value_proto_depIdxs = []int32{
 	1,  // 0: com.github.thecount.protoeval.Value.args:type_name -> com.github.thecount.protoeval.Value
-	16, // 1: com.github.thecount.protoeval.Value.scope:type_name -> google.protobuf.ListValue
+	25, // 1: com.github.thecount.protoeval.Value.scope:type_name -> google.protobuf.ListValue
 	1,  // 2: com.github.thecount.protoeval.Value.parent:type_name -> com.github.thecount.protoeval.Value
-	17, // 3: com.github.thecount.protoeval.Value.default:type_name -> google.protobuf.Empty
-	18, // 4: com.github.thecount.protoeval.Value.basic_value:type_name -> google.protobuf.Value
+	26, // 3: com.github.thecount.protoeval.Value.default:type_name -> google.protobuf.Empty
+	27, // 4: com.github.thecount.protoeval.Value.basic_value:type_name -> google.protobuf.Value
 	4,  // 5: com.github.thecount.protoeval.Value.enum:type_name -> com.github.thecount.protoeval.Value.Enum
 	5,  // 6: com.github.thecount.protoeval.Value.list:type_name -> com.github.thecount.protoeval.Value.List
 	6,  // 7: com.github.thecount.protoeval.Value.map:type_name -> com.github.thecount.protoeval.Value.Map
 	7,  // 8: com.github.thecount.protoeval.Value.message:type_name -> com.github.thecount.protoeval.Value.Message
-	19, // 9: com.github.thecount.protoeval.Value.basic_message:type_name -> google.protobuf.Any
-	20, // 10: com.github.thecount.protoeval.Value.duration:type_name -> google.protobuf.Duration
-	21, // 11: com.github.thecount.protoeval.Value.timestamp:type_name -> google.protobuf.Timestamp
+	28, // 9: com.github.thecount.protoeval.Value.basic_message:type_name -> google.protobuf.Any
+	29, // 10: com.github.thecount.protoeval.Value.duration:type_name -> google.protobuf.Duration
+	30, // 11: com.github.thecount.protoeval.Value.timestamp:type_name -> google.protobuf.Timestamp
 	1,  // 12: com.github.thecount.protoeval.Value.not:type_name -> com.github.thecount.protoeval.Value
-	12, // 13: com.github.thecount.protoeval.Value.all_of:type_name -> com.github.thecount.protoeval.Value.ValueList
-	12, // 14: com.github.thecount.protoeval.Value.any_of:type_name -> com.github.thecount.protoeval.Value.ValueList
-	12, // 15: com.github.thecount.protoeval.Value.seq:type_name -> com.github.thecount.protoeval.Value.ValueList
-	11, // 16: com.github.thecount.protoeval.Value.switch:type_name -> com.github.thecount.protoeval.Value.Switch
+	19, // 13: com.github.thecount.protoeval.Value.all_of:type_name -> com.github.thecount.protoeval.Value.ValueList
+	19, // 14: com.github.thecount.protoeval.Value.any_of:type_name -> com.github.thecount.protoeval.Value.ValueList
+	19, // 15: com.github.thecount.protoeval.Value.seq:type_name -> com.github.thecount.protoeval.Value.ValueList
+	12, // 16: com.github.thecount.protoeval.Value.switch:type_name -> com.github.thecount.protoeval.Value.Switch
 	3,  // 17: com.github.thecount.protoeval.Value.while:type_name -> com.github.thecount.protoeval.Value.Branch
-	10, // 18: com.github.thecount.protoeval.Value.store:type_name -> com.github.thecount.protoeval.Value.StoredValue
-	10, // 19: com.github.thecount.protoeval.Value.proc:type_name -> com.github.thecount.protoeval.Value.StoredValue
+	11, // 18: com.github.thecount.protoeval.Value.store:type_name -> com.github.thecount.protoeval.Value.StoredValue
+	11, // 19: com.github.thecount.protoeval.Value.proc:type_name -> com.github.thecount.protoeval.Value.StoredValue
 	1,  // 20: com.github.thecount.protoeval.Value.load:type_name -> com.github.thecount.protoeval.Value
-	8,  // 21: com.github.thecount.protoeval.Value.program:type_name -> com.github.thecount.protoeval.Value.Program
-	9,  // 22: com.github.thecount.protoeval.Value.range:type_name -> com.github.thecount.protoeval.Value.Range
-	2,  // 23: com.github.thecount.protoeval.Scope.parent:type_name -> com.github.thecount.protoeval.Scope
-	22, // 24: com.github.thecount.protoeval.Scope.field_descriptor:type_name -> google.protobuf.FieldDescriptorProto
-	19, // 25: com.github.thecount.protoeval.Scope.value:type_name -> google.protobuf.Any
-	19, // 26: com.github.thecount.protoeval.Scope.list:type_name -> google.protobuf.Any
-	15, // 27: com.github.thecount.protoeval.Scope.map:type_name -> com.github.thecount.protoeval.Scope.MapEntry
-	1,  // 28: com.github.thecount.protoeval.Value.Branch.case:type_name -> com.github.thecount.protoeval.Value
-	1,  // 29: com.github.thecount.protoeval.Value.Branch.then:type_name -> com.github.thecount.protoeval.Value
-	0,  // 30: com.github.thecount.protoeval.Value.List.kind:type_name -> com.github.thecount.protoeval.Value.Kind
-	1,  // 31: com.github.thecount.protoeval.Value.List.values:type_name -> com.github.thecount.protoeval.Value
-	0,  // 32: com.github.thecount.protoeval.Value.Map.key_kind:type_name -> com.github.thecount.protoeval.Value.Kind
-	0,  // 33: com.github.thecount.protoeval.Value.Map.value_kind:type_name -> com.github.thecount.protoeval.Value.Kind
-	13, // 34: com.github.thecount.protoeval.Value.Map.entries:type_name -> com.github.thecount.protoeval.Value.Map.Entry
-	14, // 35: com.github.thecount.protoeval.Value.Message.fields:type_name -> com.github.thecount.protoeval.Value.Message.FieldsEntry
-	1,  // 36: com.github.thecount.protoeval.Value.Range.iterable:type_name -> com.github.thecount.protoeval.Value
-	1,  // 37: com.github.thecount.protoeval.Value.Range.value:type_name -> com.github.thecount.protoeval.Value
-	1,  // 38: com.github.thecount.protoeval.Value.StoredValue.key:type_name -> com.github.thecount.protoeval.Value
-	1,  // 39: com.github.thecount.protoeval.Value.StoredValue.value:type_name -> com.github.thecount.protoeval.Value
-	3,  // 40: com.github.thecount.protoeval.Value.Switch.cases:type_name -> com.github.thecount.protoeval.Value.Branch
-	1,  // 41: com.github.thecount.protoeval.Value.Switch.default:type_name -> com.github.thecount.protoeval.Value
-	1,  // 42: com.github.thecount.protoeval.Value.ValueList.values:type_name -> com.github.thecount.protoeval.Value
-	1,  // 43: com.github.thecount.protoeval.Value.Map.Entry.key:type_name -> com.github.thecount.protoeval.Value
-	1,  // 44: com.github.thecount.protoeval.Value.Map.Entry.value:type_name -> com.github.thecount.protoeval.Value
-	1,  // 45: com.github.thecount.protoeval.Value.Message.FieldsEntry.value:type_name -> com.github.thecount.protoeval.Value
-	19, // 46: com.github.thecount.protoeval.Scope.MapEntry.value:type_name -> google.protobuf.Any
-	47, // [47:47] is the sub-list for method output_type
-	47, // [47:47] is the sub-list for method input_type
-	47, // [47:47] is the sub-list for extension type_name
-	47, // [47:47] is the sub-list for extension extendee
-	0,  // [0:47] is the sub-list for field type_name
+	9,  // 21: com.github.thecount.protoeval.Value.program:type_name -> com.github.thecount.protoeval.Value.Program
+	10, // 22: com.github.thecount.protoeval.Value.range:type_name -> com.github.thecount.protoeval.Value.Range
+	8,  // 23: com.github.thecount.protoeval.Value.patch:type_name -> com.github.thecount.protoeval.Value.Patch
+	19, // 24: com.github.thecount.protoeval.Value.eq:type_name -> com.github.thecount.protoeval.Value.ValueList
+	19, // 25: com.github.thecount.protoeval.Value.ne:type_name -> com.github.thecount.protoeval.Value.ValueList
+	19, // 26: com.github.thecount.protoeval.Value.lt:type_name -> com.github.thecount.protoeval.Value.ValueList
+	19, // 27: com.github.thecount.protoeval.Value.le:type_name -> com.github.thecount.protoeval.Value.ValueList
+	19, // 28: com.github.thecount.protoeval.Value.gt:type_name -> com.github.thecount.protoeval.Value.ValueList
+	19, // 29: com.github.thecount.protoeval.Value.ge:type_name -> com.github.thecount.protoeval.Value.ValueList
+	19, // 30: com.github.thecount.protoeval.Value.add:type_name -> com.github.thecount.protoeval.Value.ValueList
+	19, // 31: com.github.thecount.protoeval.Value.sub:type_name -> com.github.thecount.protoeval.Value.ValueList
+	19, // 32: com.github.thecount.protoeval.Value.mul:type_name -> com.github.thecount.protoeval.Value.ValueList
+	19, // 33: com.github.thecount.protoeval.Value.div:type_name -> com.github.thecount.protoeval.Value.ValueList
+	19, // 34: com.github.thecount.protoeval.Value.mod:type_name -> com.github.thecount.protoeval.Value.ValueList
+	1,  // 35: com.github.thecount.protoeval.Value.neg:type_name -> com.github.thecount.protoeval.Value
+	13, // 36: com.github.thecount.protoeval.Value.transform:type_name -> com.github.thecount.protoeval.Value.Iteration
+	13, // 37: com.github.thecount.protoeval.Value.filter:type_name -> com.github.thecount.protoeval.Value.Iteration
+	13, // 38: com.github.thecount.protoeval.Value.count:type_name -> com.github.thecount.protoeval.Value.Iteration
+	13, // 39: com.github.thecount.protoeval.Value.sum:type_name -> com.github.thecount.protoeval.Value.Iteration
+	13, // 40: com.github.thecount.protoeval.Value.min:type_name -> com.github.thecount.protoeval.Value.Iteration
+	13, // 41: com.github.thecount.protoeval.Value.max:type_name -> com.github.thecount.protoeval.Value.Iteration
+	14, // 42: com.github.thecount.protoeval.Value.fold:type_name -> com.github.thecount.protoeval.Value.Fold
+	15, // 43: com.github.thecount.protoeval.Value.sort_by:type_name -> com.github.thecount.protoeval.Value.SortBy
+	16, // 44: com.github.thecount.protoeval.Value.group_by:type_name -> com.github.thecount.protoeval.Value.GroupBy
+	17, // 45: com.github.thecount.protoeval.Value.try:type_name -> com.github.thecount.protoeval.Value.Try
+	18, // 46: com.github.thecount.protoeval.Value.fail:type_name -> com.github.thecount.protoeval.Value.Fail
+	2,  // 47: com.github.thecount.protoeval.Scope.parent:type_name -> com.github.thecount.protoeval.Scope
+	31, // 48: com.github.thecount.protoeval.Scope.field_descriptor:type_name -> google.protobuf.FieldDescriptorProto
+	28, // 49: com.github.thecount.protoeval.Scope.value:type_name -> google.protobuf.Any
+	28, // 50: com.github.thecount.protoeval.Scope.list:type_name -> google.protobuf.Any
+	24, // 51: com.github.thecount.protoeval.Scope.map:type_name -> com.github.thecount.protoeval.Scope.MapEntry
+	1,  // 52: com.github.thecount.protoeval.Value.Branch.case:type_name -> com.github.thecount.protoeval.Value
+	1,  // 53: com.github.thecount.protoeval.Value.Branch.then:type_name -> com.github.thecount.protoeval.Value
+	0,  // 54: com.github.thecount.protoeval.Value.List.kind:type_name -> com.github.thecount.protoeval.Value.Kind
+	1,  // 55: com.github.thecount.protoeval.Value.List.values:type_name -> com.github.thecount.protoeval.Value
+	0,  // 56: com.github.thecount.protoeval.Value.Map.key_kind:type_name -> com.github.thecount.protoeval.Value.Kind
+	0,  // 57: com.github.thecount.protoeval.Value.Map.value_kind:type_name -> com.github.thecount.protoeval.Value.Kind
+	20, // 58: com.github.thecount.protoeval.Value.Map.entries:type_name -> com.github.thecount.protoeval.Value.Map.Entry
+	21, // 59: com.github.thecount.protoeval.Value.Message.fields:type_name -> com.github.thecount.protoeval.Value.Message.FieldsEntry
+	25, // 60: com.github.thecount.protoeval.Value.Message.clear:type_name -> google.protobuf.ListValue
+	1,  // 61: com.github.thecount.protoeval.Value.Message.base:type_name -> com.github.thecount.protoeval.Value
+	22, // 62: com.github.thecount.protoeval.Value.Patch.operations:type_name -> com.github.thecount.protoeval.Value.Patch.Operation
+	1,  // 63: com.github.thecount.protoeval.Value.Range.iterable:type_name -> com.github.thecount.protoeval.Value
+	1,  // 64: com.github.thecount.protoeval.Value.Range.value:type_name -> com.github.thecount.protoeval.Value
+	1,  // 65: com.github.thecount.protoeval.Value.StoredValue.key:type_name -> com.github.thecount.protoeval.Value
+	1,  // 66: com.github.thecount.protoeval.Value.StoredValue.value:type_name -> com.github.thecount.protoeval.Value
+	3,  // 67: com.github.thecount.protoeval.Value.Switch.cases:type_name -> com.github.thecount.protoeval.Value.Branch
+	1,  // 68: com.github.thecount.protoeval.Value.Switch.default:type_name -> com.github.thecount.protoeval.Value
+	1,  // 69: com.github.thecount.protoeval.Value.Iteration.iterable:type_name -> com.github.thecount.protoeval.Value
+	1,  // 70: com.github.thecount.protoeval.Value.Iteration.value:type_name -> com.github.thecount.protoeval.Value
+	1,  // 71: com.github.thecount.protoeval.Value.Fold.iterable:type_name -> com.github.thecount.protoeval.Value
+	1,  // 72: com.github.thecount.protoeval.Value.Fold.init:type_name -> com.github.thecount.protoeval.Value
+	1,  // 73: com.github.thecount.protoeval.Value.Fold.value:type_name -> com.github.thecount.protoeval.Value
+	1,  // 74: com.github.thecount.protoeval.Value.SortBy.iterable:type_name -> com.github.thecount.protoeval.Value
+	23, // 75: com.github.thecount.protoeval.Value.SortBy.keys:type_name -> com.github.thecount.protoeval.Value.SortBy.Key
+	1,  // 76: com.github.thecount.protoeval.Value.GroupBy.iterable:type_name -> com.github.thecount.protoeval.Value
+	1,  // 77: com.github.thecount.protoeval.Value.GroupBy.key:type_name -> com.github.thecount.protoeval.Value
+	1,  // 78: com.github.thecount.protoeval.Value.GroupBy.value:type_name -> com.github.thecount.protoeval.Value
+	1,  // 79: com.github.thecount.protoeval.Value.Try.body:type_name -> com.github.thecount.protoeval.Value
+	1,  // 80: com.github.thecount.protoeval.Value.Try.fallback:type_name -> com.github.thecount.protoeval.Value
+	1,  // 81: com.github.thecount.protoeval.Value.Fail.message:type_name -> com.github.thecount.protoeval.Value
+	1,  // 82: com.github.thecount.protoeval.Value.ValueList.values:type_name -> com.github.thecount.protoeval.Value
+	1,  // 83: com.github.thecount.protoeval.Value.Map.Entry.key:type_name -> com.github.thecount.protoeval.Value
+	1,  // 84: com.github.thecount.protoeval.Value.Map.Entry.value:type_name -> com.github.thecount.protoeval.Value
+	1,  // 85: com.github.thecount.protoeval.Value.Message.FieldsEntry.value:type_name -> com.github.thecount.protoeval.Value
+	25, // 86: com.github.thecount.protoeval.Value.Patch.Operation.path:type_name -> google.protobuf.ListValue
+	1,  // 87: com.github.thecount.protoeval.Value.Patch.Operation.when:type_name -> com.github.thecount.protoeval.Value
+	1,  // 88: com.github.thecount.protoeval.Value.Patch.Operation.set:type_name -> com.github.thecount.protoeval.Value
+	26, // 89: com.github.thecount.protoeval.Value.Patch.Operation.clear:type_name -> google.protobuf.Empty
+	1,  // 90: com.github.thecount.protoeval.Value.Patch.Operation.append:type_name -> com.github.thecount.protoeval.Value
+	1,  // 91: com.github.thecount.protoeval.Value.SortBy.Key.value:type_name -> com.github.thecount.protoeval.Value
+	28, // 92: com.github.thecount.protoeval.Scope.MapEntry.value:type_name -> google.protobuf.Any
+	93, // [93:93] is the sub-list for method output_type
+	93, // [93:93] is the sub-list for method input_type
+	93, // [93:93] is the sub-list for extension type_name
+	93, // [93:93] is the sub-list for extension extendee
+	0,  // [0:93] is the sub-list for field type_name
 }
 
 func init() { file_protoeval_value_proto_init() }
@@ -1987,7 +3355,7 @@ func file_protoeval_value_proto_init() {
 			}
 		}
 		file_protoeval_value_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Value_Program); i {
+			switch v := v.(*Value_Patch); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1999,7 +3367,7 @@ func file_protoeval_value_proto_init() {
 			}
 		}
 		file_protoeval_value_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Value_Range); i {
+			switch v := v.(*Value_Program); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2011,7 +3379,7 @@ func file_protoeval_value_proto_init() {
 			}
 		}
 		file_protoeval_value_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Value_StoredValue); i {
+			switch v := v.(*Value_Range); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2023,7 +3391,7 @@ func file_protoeval_value_proto_init() {
 			}
 		}
 		file_protoeval_value_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Value_Switch); i {
+			switch v := v.(*Value_StoredValue); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2035,7 +3403,7 @@ func file_protoeval_value_proto_init() {
 			}
 		}
 		file_protoeval_value_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Value_ValueList); i {
+			switch v := v.(*Value_Switch); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2047,6 +3415,90 @@ func file_protoeval_value_proto_init() {
 			}
 		}
 		file_protoeval_value_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Value_Iteration); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protoeval_value_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Value_Fold); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protoeval_value_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Value_SortBy); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protoeval_value_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Value_GroupBy); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protoeval_value_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Value_Try); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protoeval_value_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Value_Fail); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protoeval_value_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Value_ValueList); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protoeval_value_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Value_Map_Entry); i {
 			case 0:
 				return &v.state
@@ -2058,6 +3510,30 @@ func file_protoeval_value_proto_init() {
 				return nil
 			}
 		}
+		file_protoeval_value_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Value_Patch_Operation); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protoeval_value_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Value_SortBy_Key); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	file_protoeval_value_proto_msgTypes[0].OneofWrappers = []interface{}{
 		(*Value_Arg)(nil),
@@ -2087,18 +3563,47 @@ func file_protoeval_value_proto_init() {
 		(*Value_Load)(nil),
 		(*Value_Program_)(nil),
 		(*Value_Range_)(nil),
+		(*Value_Patch_)(nil),
+		(*Value_Eq)(nil),
+		(*Value_Ne)(nil),
+		(*Value_Lt)(nil),
+		(*Value_Le)(nil),
+		(*Value_Gt)(nil),
+		(*Value_Ge)(nil),
+		(*Value_Add)(nil),
+		(*Value_Sub)(nil),
+		(*Value_Mul)(nil),
+		(*Value_Div)(nil),
+		(*Value_Mod)(nil),
+		(*Value_Neg)(nil),
+		(*Value_Transform)(nil),
+		(*Value_Filter)(nil),
+		(*Value_Count)(nil),
+		(*Value_Sum)(nil),
+		(*Value_Min)(nil),
+		(*Value_Max)(nil),
+		(*Value_Fold_)(nil),
+		(*Value_SortBy_)(nil),
+		(*Value_GroupBy_)(nil),
+		(*Value_Try_)(nil),
+		(*Value_Fail_)(nil),
 	}
 	file_protoeval_value_proto_msgTypes[3].OneofWrappers = []interface{}{
 		(*Value_Enum_Number)(nil),
 		(*Value_Enum_Name)(nil),
 	}
+	file_protoeval_value_proto_msgTypes[21].OneofWrappers = []interface{}{
+		(*Value_Patch_Operation_Set)(nil),
+		(*Value_Patch_Operation_Clear)(nil),
+		(*Value_Patch_Operation_Append)(nil),
+	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_protoeval_value_proto_rawDesc,
 			NumEnums:      1,
-			NumMessages:   15,
+			NumMessages:   24,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -0,0 +1,115 @@
+package protoeval
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// childValue describes a Value node directly nested in another Value node,
+// i. e., without an intermediate Value node.
+type childValue struct {
+	// path is the path of the child node relative to its parent node, e. g.,
+	// "switch.cases[1].then".
+	path string
+
+	// value is the child node.
+	value *Value
+}
+
+// isValueDescriptor reports whether the given message descriptor describes
+// a Value.
+func isValueDescriptor(desc protoreflect.MessageDescriptor) bool {
+	return desc.FullName() == (*Value)(nil).ProtoReflect().Descriptor().FullName()
+}
+
+// isValueNested reports whether the given message descriptor describes a
+// Value or a message nested in Value. Only such messages can contain Value
+// nodes.
+func isValueNested(desc protoreflect.MessageDescriptor) bool {
+	valueName := (*Value)(nil).ProtoReflect().Descriptor().FullName()
+	return desc.FullName() == valueName ||
+		strings.HasPrefix(string(desc.FullName()), string(valueName)+".")
+}
+
+// joinPath appends the given relative path to path.
+func joinPath(path, rel string) string {
+	if path == "" {
+		return rel
+	}
+	return path + "." + rel
+}
+
+// formatMapKey formats a map key for a Value node path.
+func formatMapKey(key protoreflect.MapKey) string {
+	if s, ok := key.Interface().(string); ok {
+		return strconv.Quote(s)
+	}
+	return key.String()
+}
+
+// childValues returns the Value nodes directly nested in value, in field
+// declaration order, with list elements in order and map entries in
+// ascending key order.
+func childValues(value *Value) []childValue {
+	var children []childValue
+	var walk func(msg protoreflect.Message, path string)
+	walkElement := func(m protoreflect.Message, path string) {
+		if isValueDescriptor(m.Descriptor()) {
+			children = append(children, childValue{
+				path:  path,
+				value: m.Interface().(*Value),
+			})
+		} else if isValueNested(m.Descriptor()) {
+			walk(m, path)
+		}
+	}
+	walk = func(msg protoreflect.Message, path string) {
+		fields := msg.Descriptor().Fields()
+		for i := 0; i < fields.Len(); i++ {
+			fd := fields.Get(i)
+			if !msg.Has(fd) {
+				continue
+			}
+			fieldPath := joinPath(path, string(fd.Name()))
+			switch {
+			case fd.IsList():
+				if fd.Message() == nil {
+					continue
+				}
+				list := msg.Get(fd).List()
+				for j := 0; j < list.Len(); j++ {
+					walkElement(list.Get(j).Message(),
+						fmt.Sprintf("%s[%d]", fieldPath, j))
+				}
+			case fd.IsMap():
+				if fd.MapValue().Message() == nil {
+					continue
+				}
+				mp := msg.Get(fd).Map()
+				for _, key := range sortedMapKeys(mp) {
+					walkElement(mp.Get(key).Message(),
+						fmt.Sprintf("%s[%s]", fieldPath, formatMapKey(key)))
+				}
+			case fd.Message() != nil:
+				walkElement(msg.Get(fd).Message(), fieldPath)
+			}
+		}
+	}
+	walk(value.ProtoReflect(), "")
+	return children
+}
+
+// walkValues calls visit for value and all Value nodes nested in value in
+// depth-first pre-order.
+func walkValues(
+	value *Value, path string, depth int,
+	visit func(value *Value, path string, depth int),
+) {
+	visit(value, path, depth)
+	for _, child := range childValues(value) {
+		walkValues(child.value, joinPath(path, child.path), depth+1, visit)
+	}
+}